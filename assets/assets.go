@@ -0,0 +1,8 @@
+// Package assets embeds the game assets into the binary, load them through
+// jamegam/pkg/assets instead of using FS directly.
+package assets
+
+import "embed"
+
+//go:embed *.png *.ogg *.ttf *.json
+var FS embed.FS
@@ -1,15 +1,31 @@
 package main
 
 import (
+	"jamegam/pkg/audio"
+	"jamegam/pkg/display"
 	"jamegam/pkg/game"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/settings"
+	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func configure() {
-	ebiten.SetWindowSize(1024, 1014)
+	settings.Load()
+	input.Load()
+	if err := i18n.SetLanguage(settings.Current.Language); err != nil {
+		log.Printf("Could not set language, using %s: %v", i18n.Fallback, err)
+	}
+	audio.Controller = audio.NewAudioController()
+	s := settings.Current
+
+	ebiten.SetWindowSize(int(display.Width*s.WindowScale), int(display.Height*s.WindowScale))
 	ebiten.SetWindowTitle("Bunny Game!")
-	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeDisabled)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetFullscreen(s.Fullscreen)
+	audio.Controller.SetVolumes(s.MasterVolume, s.MusicVolume, s.SfxVolume, s.UiVolume)
 }
 
 func main() {
@@ -0,0 +1,81 @@
+package animation
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Animator plays clips, one per entity.
+type Animator struct {
+	clip     *Clip
+	frame    int
+	timer    float64
+	playing  bool
+	finished bool
+}
+
+// NewAnimator creates an animator showing the first frame of the clip
+// without playing it.
+func NewAnimator(clip *Clip) *Animator {
+	return &Animator{clip: clip}
+}
+
+// Play starts the clip from its first frame.
+func (a *Animator) Play(clip *Clip) {
+	a.clip = clip
+	a.Restart()
+}
+
+// Restart plays the current clip from its first frame.
+func (a *Animator) Restart() {
+	a.frame = 0
+	a.timer = 0
+	a.playing = true
+	a.finished = false
+}
+
+// Update advances the animation by dt seconds, scale dt to change the speed.
+func (a *Animator) Update(dt float64) {
+	if !a.playing || a.clip == nil {
+		return
+	}
+	a.timer += dt
+	for a.timer >= a.clip.Duration(a.frame) {
+		a.timer -= a.clip.Duration(a.frame)
+		if a.frame+1 < a.clip.Len() {
+			a.frame++
+			continue
+		}
+		if a.clip.Loop {
+			a.frame = 0
+			continue
+		}
+		if a.clip.Rewind {
+			a.frame = 0
+		}
+		a.timer = 0
+		a.playing = false
+		a.finished = true
+		return
+	}
+}
+
+// Frame returns the image of the current frame.
+func (a *Animator) Frame() *ebiten.Image {
+	return a.clip.Frame(a.frame)
+}
+
+// Index returns the index of the current frame.
+func (a *Animator) Index() int {
+	return a.frame
+}
+
+func (a *Animator) Clip() *Clip {
+	return a.clip
+}
+
+func (a *Animator) Playing() bool {
+	return a.playing
+}
+
+// Finished reports whether a non-looping clip has played through.
+func (a *Animator) Finished() bool {
+	return a.finished
+}
@@ -0,0 +1,66 @@
+package animation
+
+import (
+	"strings"
+	"testing"
+)
+
+func testClip(t *testing.T, def clipDef) *Clip {
+	clip, err := newClip("test", def, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clip
+}
+
+func TestNewClipStrip(t *testing.T) {
+	clip := testClip(t, clipDef{Frames: 3, Duration: 0.1, Durations: []float64{0.5}})
+	if clip.Len() != 3 {
+		t.Fatalf("got %d frames, want 3", clip.Len())
+	}
+	if clip.frames[2].Min.X != 32 || clip.frames[2].Dx() != 16 {
+		t.Errorf("third frame at %v", clip.frames[2])
+	}
+	if clip.Duration(0) != 0.5 || clip.Duration(1) != 0.1 {
+		t.Errorf("durations %v", clip.durations)
+	}
+	if _, err := newClip("empty", clipDef{Duration: 0.1}, nil); err == nil {
+		t.Errorf("clip without frames accepted")
+	}
+}
+
+func TestAnimatorOnceAndLoop(t *testing.T) {
+	once := testClip(t, clipDef{Frames: 3, Duration: 0.1})
+	a := NewAnimator(once)
+	a.Update(1)
+	if a.Index() != 0 || a.Playing() {
+		t.Fatalf("animator played before Play")
+	}
+	a.Restart()
+	a.Update(0.15)
+	if a.Index() != 1 {
+		t.Errorf("frame %d after 0.15s, want 1", a.Index())
+	}
+	a.Update(0.2)
+	if !a.Finished() || a.Index() != 2 {
+		t.Errorf("once clip not finished on last frame: frame %d", a.Index())
+	}
+
+	loop := testClip(t, clipDef{Frames: 2, Duration: 0.1, Loop: true})
+	a.Play(loop)
+	a.Update(0.25)
+	if a.Index() != 0 || a.Finished() {
+		t.Errorf("loop clip at frame %d, finished %v", a.Index(), a.Finished())
+	}
+}
+
+func TestParseDefsRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"walk": {"sheet": "enemy.png", "frames": 4, "frame_widht": 16}}`,
+		`{"walk": []}`,
+	} {
+		if _, err := parseDefs(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
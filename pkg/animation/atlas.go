@@ -0,0 +1,120 @@
+// Package animation plays frame animations defined in an animation file,
+// see clipDef for the format.
+package animation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// atlasFile defines the clips of the Default atlas.
+const atlasFile = "animations.json"
+
+// Atlas holds the sprite sheets and clips of an animation file.
+type Atlas struct {
+	sheets map[string]**ebiten.Image
+	clips  map[string]*Clip
+}
+
+// Default is the atlas used by towers, enemies and effects.
+var Default *Atlas
+
+func init() {
+	var err error
+	Default, err = LoadAtlas(atlasFile)
+	lib.Must(err)
+}
+
+// LoadAtlas loads an animation file and the sheets it uses. In dev mode the
+// clips are updated in place when the file changes.
+func LoadAtlas(name string) (*Atlas, error) {
+	a := &Atlas{
+		sheets: make(map[string]**ebiten.Image),
+		clips:  make(map[string]*Clip),
+	}
+	if err := a.load(name); err != nil {
+		return nil, err
+	}
+	assets.Watch(name, func() error {
+		return a.load(name)
+	})
+	return a, nil
+}
+
+func (a *Atlas) load(name string) error {
+	reader, err := assets.Open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	defs, err := parseDefs(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	clips := make(map[string]*Clip)
+	for clipName, def := range defs {
+		sheet, err := a.sheet(def.Sheet)
+		if err != nil {
+			return err
+		}
+		clip, err := newClip(clipName, def, sheet)
+		if err != nil {
+			return err
+		}
+		clips[clipName] = clip
+	}
+
+	// Update existing clips in place, animators keep pointers to them.
+	for clipName, clip := range clips {
+		if existing, ok := a.clips[clipName]; ok {
+			*existing = *clip
+		} else {
+			a.clips[clipName] = clip
+		}
+	}
+	return nil
+}
+
+func parseDefs(r io.Reader) (map[string]clipDef, error) {
+	defs := make(map[string]clipDef)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&defs); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// sheet returns a loaded sprite sheet, loading it on first use.
+func (a *Atlas) sheet(name string) (**ebiten.Image, error) {
+	if sheet, ok := a.sheets[name]; ok {
+		return sheet, nil
+	}
+	sheet := new(*ebiten.Image)
+	if err := assets.LoadImage(sheet, name); err != nil {
+		return nil, err
+	}
+	a.sheets[name] = sheet
+	return sheet, nil
+}
+
+// Clip returns the clip with the given name, or nil if there is none.
+func (a *Atlas) Clip(name string) *Clip {
+	return a.clips[name]
+}
+
+// MustClip returns the clip with the given name and exits if there is none.
+func (a *Atlas) MustClip(name string) *Clip {
+	clip := a.Clip(name)
+	if clip == nil {
+		log.Fatalf("Unknown animation clip %s", name)
+	}
+	return clip
+}
@@ -0,0 +1,100 @@
+package animation
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Clip is a named sequence of frames on a sprite sheet.
+type Clip struct {
+	Name string
+
+	sheet     **ebiten.Image
+	frames    []image.Rectangle
+	durations []float64
+
+	// Loop restarts the clip after the last frame.
+	Loop bool
+	// Rewind shows the first frame again once a non-looping clip is done,
+	// e.g. for towers which idle on their first frame.
+	Rewind bool
+}
+
+// clipDef is the definition of a clip in the animation file. By default the
+// frames are a horizontal strip of 16x16 pixel frames starting at the top
+// left of the sheet, Rects overrides that with explicit [x, y, w, h] rects.
+type clipDef struct {
+	Sheet       string    `json:"sheet"`
+	Frames      int       `json:"frames"`
+	FrameWidth  int       `json:"frame_width"`
+	FrameHeight int       `json:"frame_height"`
+	X           int       `json:"x"`
+	Y           int       `json:"y"`
+	Rects       [][4]int  `json:"rects"`
+	Duration    float64   `json:"duration"`
+	Durations   []float64 `json:"durations"`
+	Loop        bool      `json:"loop"`
+	Rewind      bool      `json:"rewind"`
+}
+
+const defaultFrameSize = 16
+
+// newClip creates a clip from its definition, sheet is the loaded sheet.
+func newClip(name string, def clipDef, sheet **ebiten.Image) (*Clip, error) {
+	clip := &Clip{
+		Name:   name,
+		sheet:  sheet,
+		Loop:   def.Loop,
+		Rewind: def.Rewind,
+	}
+
+	if len(def.Rects) > 0 {
+		for _, r := range def.Rects {
+			clip.frames = append(clip.frames, image.Rect(r[0], r[1], r[0]+r[2], r[1]+r[3]))
+		}
+	} else {
+		w, h := def.FrameWidth, def.FrameHeight
+		if w == 0 {
+			w = defaultFrameSize
+		}
+		if h == 0 {
+			h = defaultFrameSize
+		}
+		for i := 0; i < def.Frames; i++ {
+			x := def.X + i*w
+			clip.frames = append(clip.frames, image.Rect(x, def.Y, x+w, def.Y+h))
+		}
+	}
+	if len(clip.frames) == 0 {
+		return nil, fmt.Errorf("clip %s has no frames", name)
+	}
+
+	for i := range clip.frames {
+		duration := def.Duration
+		if i < len(def.Durations) {
+			duration = def.Durations[i]
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("clip %s frame %d has no duration", name, i)
+		}
+		clip.durations = append(clip.durations, duration)
+	}
+	return clip, nil
+}
+
+// Len returns the number of frames.
+func (c *Clip) Len() int {
+	return len(c.frames)
+}
+
+// Frame returns the image of the given frame.
+func (c *Clip) Frame(i int) *ebiten.Image {
+	return (*c.sheet).SubImage(c.frames[i]).(*ebiten.Image)
+}
+
+// Duration returns how long the given frame is shown, in seconds.
+func (c *Clip) Duration(i int) float64 {
+	return c.durations[i]
+}
@@ -0,0 +1,91 @@
+// Package assets is the single place assets are loaded from. Files come from
+// the assets embedded into the binary, unless an override directory is set
+// and contains a file of the same name, which allows modding and iterating
+// on assets without rebuilding.
+package assets
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	embedded "jamegam/assets"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// OverrideDirEnv is the environment variable naming the override directory.
+const OverrideDirEnv = "JAMEGAM_ASSET_DIR"
+
+var overrideDir string
+
+func init() {
+	SetOverrideDir(os.Getenv(OverrideDirEnv))
+	if os.Getenv(DevModeEnv) != "" {
+		EnableDevMode()
+	}
+}
+
+// SetOverrideDir sets the directory whose files take precedence over the
+// embedded ones, an empty dir disables overrides.
+func SetOverrideDir(dir string) {
+	overrideDir = dir
+	if dir != "" {
+		log.Printf("Loading assets from %s before the embedded ones", dir)
+	}
+}
+
+// OverrideDir returns the current override directory, empty if none.
+func OverrideDir() string {
+	return overrideDir
+}
+
+// Open opens an asset by file name, e.g. "map.png".
+func Open(name string) (io.ReadSeekCloser, error) {
+	if overrideDir != "" {
+		file, err := os.Open(filepath.Join(overrideDir, name))
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	file, err := embedded.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	seeker, ok := file.(io.ReadSeekCloser)
+	if !ok {
+		file.Close()
+		return nil, fmt.Errorf("asset %s is not seekable", name)
+	}
+	return seeker, nil
+}
+
+// ReadFile reads a whole asset.
+func ReadFile(name string) ([]byte, error) {
+	file, err := Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// Image loads an image asset.
+func Image(name string) (*ebiten.Image, error) {
+	file, err := Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	image, _, err := ebitenutil.NewImageFromReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s: %w", name, err)
+	}
+	return image, nil
+}
@@ -0,0 +1,128 @@
+package assets
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DevModeEnv is the environment variable enabling dev mode, in which changed
+// files in the override directory are reloaded while the game runs.
+const DevModeEnv = "JAMEGAM_DEV"
+
+// defaultDevDir is watched in dev mode if no override directory is set, it
+// matches running the game from the repository root.
+const defaultDevDir = "assets"
+
+// pollInterval is the time in seconds between checks for changed files.
+const pollInterval = 0.5
+
+type watch struct {
+	modTime time.Time
+	reloads []func() error
+}
+
+var (
+	devMode   bool
+	watches   = make(map[string]*watch)
+	pollTimer float64
+)
+
+// EnableDevMode turns on hot reloading of watched assets, see Watch.
+func EnableDevMode() {
+	devMode = true
+	if overrideDir == "" {
+		SetOverrideDir(defaultDevDir)
+	}
+	log.Printf("Dev mode, reloading changed assets from %s", overrideDir)
+}
+
+func DevMode() bool {
+	return devMode
+}
+
+// Watch calls reload whenever the named asset changes in the override
+// directory. Does nothing outside of dev mode.
+func Watch(name string, reload func() error) {
+	if !devMode {
+		return
+	}
+	w, ok := watches[name]
+	if !ok {
+		w = &watch{modTime: modTime(name)}
+		watches[name] = w
+	}
+	w.reloads = append(w.reloads, reload)
+}
+
+// Update checks watched assets for changes and reloads them. It must be called
+// from the game loop so assets are only swapped between frames.
+func Update(dt float64) {
+	if !devMode {
+		return
+	}
+	pollTimer += dt
+	if pollTimer < pollInterval {
+		return
+	}
+	pollTimer = 0
+
+	for name, w := range watches {
+		current := modTime(name)
+		if current.Equal(w.modTime) {
+			continue
+		}
+		w.modTime = current
+		log.Printf("Reloading %s", name)
+		for _, reload := range w.reloads {
+			if err := reload(); err != nil {
+				log.Printf("Reloading %s failed, keeping the old version: %v", name, err)
+			}
+		}
+	}
+}
+
+// modTime returns the modification time of an asset in the override
+// directory, or the zero time if it is not overridden.
+func modTime(name string) time.Time {
+	info, err := os.Stat(filepath.Join(overrideDir, name))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// LoadImage loads an image asset into dst and keeps it up to date in dev mode.
+func LoadImage(dst **ebiten.Image, name string) error {
+	image, err := Image(name)
+	if err != nil {
+		return err
+	}
+	*dst = image
+	Watch(name, func() error {
+		return reloadImage(dst, name)
+	})
+	return nil
+}
+
+// reloadImage redraws images of unchanged size in place, so towers and sub
+// images holding on to the old image see the change. Otherwise dst is
+// replaced and only new users get the new image.
+func reloadImage(dst **ebiten.Image, name string) error {
+	image, err := Image(name)
+	if err != nil {
+		return err
+	}
+	if (*dst).Bounds() != image.Bounds() {
+		log.Printf("Size of %s changed, only new users see the change", name)
+		*dst = image
+		return nil
+	}
+	(*dst).Clear()
+	(*dst).DrawImage(image, nil)
+	image.Deallocate()
+	return nil
+}
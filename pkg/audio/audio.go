@@ -3,6 +3,7 @@ package audio
 import (
 	"bytes"
 	"io"
+	"jamegam/pkg/assets"
 	"jamegam/pkg/lib"
 	"log"
 	"math/rand"
@@ -10,120 +11,218 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/solarlune/resound/effects"
 )
 
+// maxVoicesPerSound caps the number of simultaneously playing instances of
+// a single sound, further plays are dropped.
+const maxVoicesPerSound = 6
+
+// Backend plays sounds and music. The game talks to the global Controller,
+// which is silent until main installs the ebiten backend, so packages using
+// audio can be tested without an audio device or assets.
+type Backend interface {
+	// Play plays a sound effect from the manifest on the sfx bus.
+	Play(sound string)
+	// PlayUI plays an interface sound from the manifest on the ui bus.
+	PlayUI(sound string)
+	// PlayOnBus plays a sound from the manifest on the given bus.
+	PlayOnBus(bus Bus, sound string)
+	// PlayStinger plays a one-shot music cue and ducks the music below it.
+	PlayStinger(name string)
+	// SetMusicState crossfades to the music fitting the given state.
+	SetMusicState(state MusicState)
+	ToggleMute()
+	IsMuted() bool
+	// SetVolumes sets the master and per-bus volumes, each in the range 0 to 1.
+	SetVolumes(master, music, sfx, ui float64)
+	// Duck lowers the music for big events, see Mixer.Duck.
+	Duck(level, duration float64)
+	// Update advances time based effects like ducking, must be called every tick.
+	Update(dt float64)
+}
+
+var _ Backend = &AudioController{}
+
+// AudioController is the Backend playing through ebiten.
 type AudioController struct {
 	audioCtx     *audio.Context
+	manifest     map[string]soundDef
 	soundPlayers map[string][]PitchedPlayer
 	sounds       map[string][]byte
+	missing      map[string]bool
+	watched      map[string]bool
 
-	ostPlayer *audio.Player
-	mmPlayer  *audio.Player
-	isMuted   bool
+	music   *musicSystem
+	isMuted bool
+
+	Mixer *Mixer
 }
 
+// ostBaseVolume is the volume of the music at full music and master volume.
+const ostBaseVolume = 0.5
+
 type PitchedPlayer struct {
 	player       *audio.Player
 	pitchShifter *effects.PitchShift
 }
 
-func init() {
-	Controller = AudioController{
-		audioCtx:     audio.NewContext(44100),
+// NewAudioController creates the ebiten audio context and loads the sound
+// manifest, sounds themselves are decoded on first use.
+func NewAudioController() *AudioController {
+	audioCtx := audio.NewContext(44100)
+	a := &AudioController{
+		audioCtx:     audioCtx,
+		manifest:     loadManifest(),
+		music:        newMusicSystem(audioCtx),
 		soundPlayers: make(map[string][]PitchedPlayer),
 		sounds:       make(map[string][]byte),
+		missing:      make(map[string]bool),
+		watched:      make(map[string]bool),
+		Mixer:        NewMixer(),
 	}
+	assets.Watch(manifestFile, func() error {
+		manifest, err := readManifest()
+		if err != nil {
+			return err
+		}
+		a.manifest = manifest
+		return nil
+	})
+	return a
+}
 
-	sounds := []string{
-		"audio",
-		"test_pew",
-		"enemy_death_poof",
-		"tower_cash_shot",
-		"tower_cash_shot2",
-		"basic_tower_shoot",
-		"ice_tower_shoot",
-		"aoe_tower_shoot",
-		"aoe_tower_explosion",
-		"click",
-		"error",
-		"notification",
-		"build_tower",
+// loadSound decodes a sound file on first use and reports whether it is
+// available. Missing files are only reported once.
+func (a *AudioController) loadSound(file string) bool {
+	if _, ok := a.sounds[file]; ok {
+		return true
 	}
-
-	for _, sound := range sounds {
-		Controller.loadSound(sound)
+	if a.missing[file] {
+		return false
 	}
+	a.missing[file] = true
 
+	soundReader, err := assets.Open(file + ".ogg")
+	if err != nil {
+		log.Printf("Sound %s not found: %v", file, err)
+		return false
+	}
+	defer soundReader.Close()
+	streamReader, err := vorbis.Decode(a.audioCtx, soundReader)
+	if err != nil {
+		log.Printf("Sound %s could not be decoded: %v", file, err)
+		return false
+	}
+	data, err := io.ReadAll(streamReader)
+	if err != nil {
+		log.Printf("Sound %s could not be read: %v", file, err)
+		return false
+	}
+	a.sounds[file] = data
+	delete(a.missing, file)
+	if !a.watched[file] {
+		a.watched[file] = true
+		assets.Watch(file+".ogg", func() error {
+			a.unloadSound(file)
+			return nil
+		})
+	}
+	return true
 }
 
-func (a *AudioController) loadSound(name string) {
-	soundReader, err := ebitenutil.OpenFile(name + ".ogg")
-	lib.Must(err)
-	streamReader, err := vorbis.Decode(a.audioCtx, soundReader)
-	lib.Must(err)
-	a.sounds[name], err = io.ReadAll(streamReader)
-	lib.Must(err)
+// unloadSound drops a decoded sound and its players, so the next play decodes
+// the file again.
+func (a *AudioController) unloadSound(file string) {
+	for _, player := range a.soundPlayers[file] {
+		player.player.Close()
+	}
+	delete(a.soundPlayers, file)
+	delete(a.sounds, file)
+	delete(a.missing, file)
 }
 
-func (a *AudioController) PlayOst() {
-	ostReader, err := ebitenutil.OpenFile("ost.ogg")
-	lib.Must(err)
-	streamReader, err := vorbis.Decode(a.audioCtx, ostReader)
-	lib.Must(err)
-	loop := audio.NewInfiniteLoop(streamReader, streamReader.Length())
-	player, err := a.audioCtx.NewPlayer(loop)
-	lib.Must(err)
-	player.SetVolume(0.5)
-	player.Play()
-	a.ostPlayer = player
-	// player, err := a.audioCtx.NewPlayer(streamReader)
-	// lib.Must(err)
-	// audio.NewInfiniteLoop(player, streamReader.Length()).Play()
-	// player.SetVolume(0.5)
-	// player.Play()
+// soundDef returns the manifest entry of a sound, or the defaults for sounds
+// not listed in the manifest.
+func (a *AudioController) soundDef(sound string) soundDef {
+	if def, ok := a.manifest[sound]; ok {
+		return def
+	}
+	return soundDef{}.withDefaults(sound)
 }
 
-func (a *AudioController) PlayMainMenuOst() {
-	ostReader, err := ebitenutil.OpenFile("music_menu.ogg")
-	lib.Must(err)
-	streamReader, err := vorbis.Decode(a.audioCtx, ostReader)
-	lib.Must(err)
-	loop := audio.NewInfiniteLoop(streamReader, streamReader.Length())
-	player, err := a.audioCtx.NewPlayer(loop)
-	lib.Must(err)
-	player.SetVolume(0.5)
-	player.Play()
-	a.mmPlayer = player
+func (a *AudioController) SetMusicState(state MusicState) {
+	a.music.setState(state)
 }
 
-func (a *AudioController) StopMainMenuOst() {
-	a.mmPlayer.Pause()
-	a.mmPlayer.Close()
+func (a *AudioController) PlayStinger(name string) {
+	a.Duck(0.3, 2.0)
+	if a.isMuted {
+		return
+	}
+	a.music.playStinger(name, ostBaseVolume*a.Mixer.UnduckedVolume(BusMusic))
 }
 
 func (a *AudioController) ToggleMute() {
 	a.isMuted = !a.isMuted
+}
+
+func (a *AudioController) IsMuted() bool {
+	return a.isMuted
+}
+
+func (a *AudioController) SetVolumes(master, music, sfx, ui float64) {
+	a.Mixer.SetMaster(master)
+	a.Mixer.SetBusVolume(BusMusic, music)
+	a.Mixer.SetBusVolume(BusSfx, sfx)
+	a.Mixer.SetBusVolume(BusUI, ui)
+}
+
+func (a *AudioController) Duck(level, duration float64) {
+	a.Mixer.Duck(level, duration)
+}
+
+func (a *AudioController) Update(dt float64) {
+	a.Mixer.Update(dt)
+	a.music.update(dt, a.currentMusicVolume())
+}
+
+func (a *AudioController) currentMusicVolume() float64 {
 	if a.isMuted {
-		a.ostPlayer.SetVolume(0)
-	} else {
-		a.ostPlayer.SetVolume(0.5)
+		return 0
 	}
+	return ostBaseVolume * a.Mixer.Volume(BusMusic)
+}
+
+func (a *AudioController) Play(sound string) {
+	a.PlayOnBus(BusSfx, sound)
 }
 
-func (a *AudioController) Play(sound string, variance float64) {
+func (a *AudioController) PlayUI(sound string) {
+	a.PlayOnBus(BusUI, sound)
+}
+
+// PlayOnBus plays a random variant of a sound on the given bus, using the
+// volume and pitch variance from the manifest.
+func (a *AudioController) PlayOnBus(bus Bus, sound string) {
 	if a.isMuted {
 		return
 	}
-	variance = (rand.Float64() - 0.5) * variance * 2
-	log.Printf("Playing sound %s with variance %f", sound, variance)
-	if _, ok := a.sounds[sound]; !ok {
-		log.Printf("Sound %s not loaded", sound)
+	def := a.soundDef(sound)
+	// The voice limit counts all variants of a sound together.
+	if a.playingVoices(def) >= maxVoicesPerSound {
+		return
+	}
+	sound = def.pickVariant()
+	if !a.loadSound(sound) {
+		return
 	}
+	volume := a.Mixer.Volume(bus) * *def.Volume
+	variance := (rand.Float64() - 0.5) * def.Variance * 2
 	for _, player := range a.soundPlayers[sound] {
 		if !player.player.IsPlaying() {
 			player.pitchShifter.SetPitch(1.0 + variance)
+			player.player.SetVolume(volume)
 			player.player.Rewind()
 			player.player.Play()
 			return
@@ -139,8 +238,9 @@ func (a *AudioController) Play(sound string, variance float64) {
 	reader := bytes.NewReader(a.sounds[sound])
 	pshift := effects.NewPitchShift(256).SetSource(reader).SetStrength(0.7).SetPitch(1.0 + variance)
 	player, err := a.audioCtx.NewPlayer(pshift)
-	player.SetBufferSize(time.Millisecond * 500)
 	lib.Must(err)
+	player.SetBufferSize(time.Millisecond * 500)
+	player.SetVolume(volume)
 	a.soundPlayers[sound] = append(a.soundPlayers[sound], PitchedPlayer{
 		player:       player,
 		pitchShifter: pshift,
@@ -148,4 +248,19 @@ func (a *AudioController) Play(sound string, variance float64) {
 	player.Play()
 }
 
-var Controller AudioController
+// playingVoices returns the number of players playing any variant of the
+// sound.
+func (a *AudioController) playingVoices(def soundDef) int {
+	voices := 0
+	for _, variant := range def.Variants {
+		for _, player := range a.soundPlayers[variant] {
+			if player.player.IsPlaying() {
+				voices++
+			}
+		}
+	}
+	return voices
+}
+
+// Controller is the backend used by the game, see Backend.
+var Controller Backend = NewSilentController()
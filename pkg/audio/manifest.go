@@ -0,0 +1,83 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"log"
+	"math/rand"
+)
+
+// manifestFile describes all sound effects, see soundDef.
+const manifestFile = "sounds.json"
+
+// soundDef is the manifest entry of a sound effect.
+type soundDef struct {
+	// Variants are the ogg files (without extension) one of which is picked
+	// at random on every play. Defaults to the name of the sound.
+	Variants []string `json:"variants"`
+	// Variance is the default random pitch variance.
+	Variance float64 `json:"variance"`
+	// Volume is multiplied with the bus volume. Defaults to 1.
+	Volume *float64 `json:"volume"`
+}
+
+// parseManifest reads a sound manifest, filling in the defaults.
+func parseManifest(r io.Reader) (map[string]soundDef, error) {
+	manifest := make(map[string]soundDef)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, err
+	}
+	for name, def := range manifest {
+		for _, variant := range def.Variants {
+			if variant == "" {
+				return nil, fmt.Errorf("sound %s has an empty variant", name)
+			}
+		}
+		if def.Variance < 0 || (def.Volume != nil && *def.Volume < 0) {
+			return nil, fmt.Errorf("sound %s has a negative variance or volume", name)
+		}
+		manifest[name] = def.withDefaults(name)
+	}
+	return manifest, nil
+}
+
+// withDefaults fills in the fields missing from the manifest.
+func (d soundDef) withDefaults(name string) soundDef {
+	if len(d.Variants) == 0 {
+		d.Variants = []string{name}
+	}
+	if d.Volume == nil {
+		volume := 1.0
+		d.Volume = &volume
+	}
+	return d
+}
+
+// pickVariant returns the file of a random variant.
+func (d soundDef) pickVariant() string {
+	return d.Variants[rand.Intn(len(d.Variants))]
+}
+
+// loadManifest loads the sound manifest. A missing or broken manifest is not
+// fatal, every sound then plays its own file with default settings.
+func loadManifest() map[string]soundDef {
+	manifest, err := readManifest()
+	if err != nil {
+		log.Printf("Sound manifest not loaded, using defaults: %v", err)
+		return make(map[string]soundDef)
+	}
+	return manifest
+}
+
+func readManifest() (map[string]soundDef, error) {
+	reader, err := assets.Open(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return parseManifest(reader)
+}
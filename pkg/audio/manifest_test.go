@@ -0,0 +1,20 @@
+package audio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseManifestRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"click": {"volum": 0.5}}`,
+		`{"click": {"volume": -0.5}}`,
+		`{"click": {"variance": -0.1}}`,
+		`{"click": {"variants": ["click", ""]}}`,
+		`{"click": []}`,
+	} {
+		if _, err := parseManifest(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
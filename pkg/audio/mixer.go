@@ -0,0 +1,78 @@
+package audio
+
+// Bus is a group of sounds sharing a volume.
+type Bus int
+
+const (
+	BusMusic Bus = iota
+	BusSfx
+	BusUI
+	busCount
+)
+
+// Mixer holds the master and per-bus volumes and handles ducking of the
+// music bus.
+type Mixer struct {
+	master  float64
+	volumes [busCount]float64
+
+	duckLevel    float64 // music is multiplied by this while ducked
+	duckTimer    float64 // time left at full duck
+	duckRecovery float64 // seconds to fade back to full volume
+}
+
+func NewMixer() *Mixer {
+	m := &Mixer{
+		master:       1.0,
+		duckLevel:    1.0,
+		duckRecovery: 0.5,
+	}
+	for i := range m.volumes {
+		m.volumes[i] = 1.0
+	}
+	return m
+}
+
+// SetMaster sets the master volume in the range 0 to 1.
+func (m *Mixer) SetMaster(volume float64) {
+	m.master = min(1, max(0, volume))
+}
+
+// SetBusVolume sets the volume of a bus in the range 0 to 1.
+func (m *Mixer) SetBusVolume(bus Bus, volume float64) {
+	m.volumes[bus] = min(1, max(0, volume))
+}
+
+// Volume returns the effective volume of a bus, including master volume and
+// ducking.
+func (m *Mixer) Volume(bus Bus) float64 {
+	v := m.UnduckedVolume(bus)
+	if bus == BusMusic {
+		v *= m.duckLevel
+	}
+	return v
+}
+
+// UnduckedVolume returns the volume of a bus ignoring ducking, for sounds
+// that cause the duck themselves.
+func (m *Mixer) UnduckedVolume(bus Bus) float64 {
+	return m.master * m.volumes[bus]
+}
+
+// Duck lowers the music bus to the given level for the given duration in
+// seconds, after which it recovers. A stronger duck overrides a weaker one.
+func (m *Mixer) Duck(level, duration float64) {
+	if level <= m.duckLevel {
+		m.duckLevel = level
+	}
+	m.duckTimer = max(m.duckTimer, duration)
+}
+
+// Update advances the ducking.
+func (m *Mixer) Update(dt float64) {
+	if m.duckTimer > 0 {
+		m.duckTimer -= dt
+		return
+	}
+	m.duckLevel = min(1.0, m.duckLevel+dt/m.duckRecovery)
+}
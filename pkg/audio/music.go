@@ -0,0 +1,212 @@
+package audio
+
+import (
+	"io"
+	"jamegam/pkg/assets"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/solarlune/resound/effects"
+)
+
+// MusicState describes what kind of music should be playing.
+type MusicState int
+
+const (
+	MusicSilent MusicState = iota
+	MusicMenu
+	MusicCalm    // prep phase between waves
+	MusicIntense // during a wave
+)
+
+// musicFadeTime is the duration of a crossfade in seconds.
+const musicFadeTime = 1.5
+
+// StingerBoss is played when a boss enters the map.
+const StingerBoss = "notification"
+
+// musicLayer describes how a looping track is built from a music file.
+type musicLayer struct {
+	file    string
+	group   string  // tracks of a group are started together to stay in sync
+	lowpass float64 // strength of a low-pass filter, 0 plays the file as is
+}
+
+// The calm and intense layers are made from the menu music, the calm one is
+// muffled so the song opens up when a wave starts.
+var (
+	musicLayerMenu    = musicLayer{file: "music_menu", group: "menu"}
+	musicLayerCalm    = musicLayer{file: "music_menu", group: "ost", lowpass: 0.85}
+	musicLayerIntense = musicLayer{file: "music_menu", group: "ost"}
+)
+
+// musicTrack is a looping music player whose gain is faded towards a target.
+type musicTrack struct {
+	player *audio.Player
+	group  string
+	gain   float64
+	target float64
+}
+
+// musicSystem crossfades between looping tracks depending on the music
+// state. The calm and intense tracks are layers of the same song, they play
+// in sync and only their gains change.
+type musicSystem struct {
+	audioCtx *audio.Context
+	tracks   map[musicLayer]*musicTrack
+	stingers map[string]*audio.Player
+	missing  map[string]bool
+	state    MusicState
+}
+
+func newMusicSystem(audioCtx *audio.Context) *musicSystem {
+	return &musicSystem{
+		audioCtx: audioCtx,
+		tracks:   make(map[musicLayer]*musicTrack),
+		stingers: make(map[string]*audio.Player),
+		missing:  make(map[string]bool),
+	}
+}
+
+// decode opens and decodes an ogg file. Missing music is not fatal, the game
+// simply stays silent.
+func (m *musicSystem) decode(name string) *vorbis.Stream {
+	if m.missing[name] {
+		return nil
+	}
+	reader, err := assets.Open(name + ".ogg")
+	if err != nil {
+		log.Printf("Music %s not found: %v", name, err)
+		m.missing[name] = true
+		return nil
+	}
+	stream, err := vorbis.Decode(m.audioCtx, reader)
+	if err != nil {
+		log.Printf("Music %s could not be decoded: %v", name, err)
+		m.missing[name] = true
+		return nil
+	}
+	return stream
+}
+
+// track returns the looping track of the layer, loading it on first use.
+// Returns nil if the music file is missing.
+func (m *musicSystem) track(layer musicLayer) *musicTrack {
+	if t, ok := m.tracks[layer]; ok {
+		return t
+	}
+	stream := m.decode(layer.file)
+	if stream == nil {
+		return nil
+	}
+	var source io.ReadSeeker = stream
+	if layer.lowpass > 0 {
+		source = effects.NewLowpassFilter().SetSource(stream).SetStrength(layer.lowpass)
+	}
+	loop := audio.NewInfiniteLoop(source, stream.Length())
+	player, err := m.audioCtx.NewPlayer(loop)
+	if err != nil {
+		log.Printf("Music %s could not be played: %v", layer.file, err)
+		m.missing[layer.file] = true
+		return nil
+	}
+	player.SetVolume(0)
+	t := &musicTrack{player: player, group: layer.group}
+	m.tracks[layer] = t
+	return t
+}
+
+// startGroup starts all paused tracks of a group from the beginning, so
+// layers stay in sync.
+func (m *musicSystem) startGroup(group string) {
+	for _, t := range m.tracks {
+		if t.group == group && t.player.IsPlaying() {
+			return
+		}
+	}
+	for _, t := range m.tracks {
+		if t.group == group {
+			t.player.Rewind()
+			t.player.Play()
+		}
+	}
+}
+
+func (m *musicSystem) setState(state MusicState) {
+	if state == m.state {
+		return
+	}
+	m.state = state
+
+	for _, t := range m.tracks {
+		t.target = 0
+	}
+
+	switch state {
+	case MusicMenu:
+		if menu := m.track(musicLayerMenu); menu != nil {
+			menu.target = 1
+			m.startGroup(musicLayerMenu.group)
+		}
+	case MusicCalm, MusicIntense:
+		calm := m.track(musicLayerCalm)
+		intense := m.track(musicLayerIntense)
+		m.startGroup(musicLayerCalm.group)
+		if intense == nil || state == MusicCalm {
+			if calm != nil {
+				calm.target = 1
+			}
+		} else {
+			intense.target = 1
+			if calm != nil {
+				calm.target = 0.3
+			}
+		}
+	}
+}
+
+// playStinger plays a one-shot music cue over the current music.
+func (m *musicSystem) playStinger(name string, volume float64) {
+	player, ok := m.stingers[name]
+	if !ok {
+		stream := m.decode(name)
+		if stream == nil {
+			return
+		}
+		var err error
+		player, err = m.audioCtx.NewPlayer(stream)
+		if err != nil {
+			log.Printf("Stinger %s could not be played: %v", name, err)
+			m.missing[name] = true
+			return
+		}
+		m.stingers[name] = player
+	}
+	player.SetVolume(volume)
+	player.Rewind()
+	player.Play()
+}
+
+// update fades all tracks towards their target gain and applies the music
+// bus volume. Groups that faded out completely are paused.
+func (m *musicSystem) update(dt, volume float64) {
+	step := dt / musicFadeTime
+	groupAudible := make(map[string]bool)
+	for _, t := range m.tracks {
+		if t.gain < t.target {
+			t.gain = min(t.target, t.gain+step)
+		} else if t.gain > t.target {
+			t.gain = max(t.target, t.gain-step)
+		}
+		t.player.SetVolume(t.gain * volume)
+		if t.gain > 0 || t.target > 0 {
+			groupAudible[t.group] = true
+		}
+	}
+	for _, t := range m.tracks {
+		if !groupAudible[t.group] && t.player.IsPlaying() {
+			t.player.Pause()
+		}
+	}
+}
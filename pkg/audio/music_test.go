@@ -0,0 +1,23 @@
+package audio
+
+import (
+	"jamegam/pkg/assets"
+	"testing"
+)
+
+func TestEveryMusicFileExists(t *testing.T) {
+	files := []string{
+		musicLayerMenu.file,
+		musicLayerCalm.file,
+		musicLayerIntense.file,
+		StingerBoss,
+	}
+	for _, name := range files {
+		file, err := assets.Open(name + ".ogg")
+		if err != nil {
+			t.Errorf("music %s: %v", name, err)
+			continue
+		}
+		file.Close()
+	}
+}
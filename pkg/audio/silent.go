@@ -0,0 +1,88 @@
+package audio
+
+var _ Backend = &SilentController{}
+
+// SilentController is a Backend that plays nothing and records what would
+// have been played, for headless runs and tests.
+type SilentController struct {
+	played     []string
+	stingers   []string
+	musicState MusicState
+	isMuted    bool
+}
+
+func NewSilentController() *SilentController {
+	return &SilentController{}
+}
+
+func (s *SilentController) Play(sound string) {
+	s.PlayOnBus(BusSfx, sound)
+}
+
+func (s *SilentController) PlayUI(sound string) {
+	s.PlayOnBus(BusUI, sound)
+}
+
+func (s *SilentController) PlayOnBus(bus Bus, sound string) {
+	if s.isMuted {
+		return
+	}
+	s.played = append(s.played, sound)
+}
+
+func (s *SilentController) PlayStinger(name string) {
+	if s.isMuted {
+		return
+	}
+	s.stingers = append(s.stingers, name)
+}
+
+func (s *SilentController) SetMusicState(state MusicState) {
+	s.musicState = state
+}
+
+func (s *SilentController) ToggleMute() {
+	s.isMuted = !s.isMuted
+}
+
+func (s *SilentController) IsMuted() bool {
+	return s.isMuted
+}
+
+func (s *SilentController) SetVolumes(master, music, sfx, ui float64) {}
+
+func (s *SilentController) Duck(level, duration float64) {}
+
+func (s *SilentController) Update(dt float64) {}
+
+// Played returns the sounds played so far, oldest first.
+func (s *SilentController) Played() []string {
+	return s.played
+}
+
+// PlayCount returns how often the given sound was played.
+func (s *SilentController) PlayCount(sound string) int {
+	count := 0
+	for _, played := range s.played {
+		if played == sound {
+			count++
+		}
+	}
+	return count
+}
+
+// Stingers returns the music stingers played so far, oldest first.
+func (s *SilentController) Stingers() []string {
+	return s.stingers
+}
+
+// MusicState returns the last requested music state.
+func (s *SilentController) MusicState() MusicState {
+	return s.musicState
+}
+
+// Reset forgets everything recorded so far.
+func (s *SilentController) Reset() {
+	s.played = nil
+	s.stingers = nil
+}
@@ -0,0 +1,36 @@
+package audio
+
+import "testing"
+
+func TestSilentControllerRecords(t *testing.T) {
+	previous := Controller
+	t.Cleanup(func() { Controller = previous })
+	silent := NewSilentController()
+	Controller = silent
+
+	Controller.Play("aoe_tower_explosion")
+	Controller.PlayUI("click")
+	Controller.Play("aoe_tower_explosion")
+	Controller.SetMusicState(MusicIntense)
+
+	if got := silent.PlayCount("aoe_tower_explosion"); got != 2 {
+		t.Errorf("aoe_tower_explosion played %d times, want 2", got)
+	}
+	if got := silent.PlayCount("click"); got != 1 {
+		t.Errorf("click played %d times, want 1", got)
+	}
+	if silent.MusicState() != MusicIntense {
+		t.Errorf("music state %v, want %v", silent.MusicState(), MusicIntense)
+	}
+
+	Controller.ToggleMute()
+	Controller.Play("click")
+	if got := silent.PlayCount("click"); got != 1 {
+		t.Errorf("muted click was recorded")
+	}
+
+	silent.Reset()
+	if len(silent.Played()) != 0 {
+		t.Errorf("played not cleared by Reset")
+	}
+}
@@ -0,0 +1,177 @@
+// Package camera maps the game world onto the screen, with zoom, panning and
+// trauma based screenshake.
+package camera
+
+import (
+	"jamegam/pkg/lib"
+	"jamegam/pkg/settings"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	maxZoom = 2.0
+	// traumaDecay is the trauma removed per second.
+	traumaDecay = 1.2
+	// maxShakeOffset is the offset in pixels at full trauma.
+	maxShakeOffset = 24.0
+	// maxShakeAngle is the rotation in radians at full trauma.
+	maxShakeAngle = 0.03
+	// shakeFrequency is how fast the shake changes direction.
+	shakeFrequency = 25.0
+)
+
+// Camera shows a part of the world in a viewport on the screen. The view is
+// never zoomed out further than the world, so no void is shown.
+type Camera struct {
+	viewX, viewY, viewW, viewH float32
+	worldW, worldH             float32
+
+	center lib.Vec2
+	zoom   float32
+
+	trauma    float64
+	shakeTime float64
+}
+
+// Main is the camera of the gameplay scene.
+var Main = New(0, 0, 1024, 768, 1024, 768)
+
+// New creates a camera for a world of the given size, shown in the viewport
+// at viewX, viewY on the screen.
+func New(viewX, viewY, viewW, viewH, worldW, worldH float32) *Camera {
+	c := &Camera{
+		viewX:  viewX,
+		viewY:  viewY,
+		viewW:  viewW,
+		viewH:  viewH,
+		worldW: worldW,
+		worldH: worldH,
+	}
+	c.Reset()
+	return c
+}
+
+// Reset shows the whole world and stops shaking.
+func (c *Camera) Reset() {
+	c.center = lib.NewVec2(c.worldW/2, c.worldH/2)
+	c.zoom = 1
+	c.trauma = 0
+	c.clamp()
+}
+
+// AddTrauma makes the camera shake, the shake is the square of the trauma so
+// small hits are subtle while many big ones add up. Does nothing if
+// screenshake or motion is reduced in the settings.
+func (c *Camera) AddTrauma(amount float64) {
+	if !settings.Current.Screenshake || settings.Current.ReducedMotion {
+		return
+	}
+	c.trauma = min(1, c.trauma+amount)
+}
+
+// StopShake settles the camera at once.
+func (c *Camera) StopShake() {
+	c.trauma = 0
+}
+
+func (c *Camera) Update(dt float64) {
+	c.trauma = max(0, c.trauma-traumaDecay*dt)
+	c.shakeTime += dt
+}
+
+// Pan moves the camera by a distance in screen pixels.
+func (c *Camera) Pan(dx, dy float32) {
+	c.center = c.center.Sub(lib.NewVec2(dx, dy).Div(c.zoom))
+	c.clamp()
+}
+
+// ShowRect pans as little as needed to bring the world rect into view.
+func (c *Camera) ShowRect(x, y, w, h float32) {
+	halfW := c.viewW / 2 / c.zoom
+	halfH := c.viewH / 2 / c.zoom
+	c.center.X = min(x+halfW, max(x+w-halfW, c.center.X))
+	c.center.Y = min(y+halfH, max(y+h-halfH, c.center.Y))
+	c.clamp()
+}
+
+// ZoomAt changes the zoom by the given factor, keeping the world point below
+// the screen point in place.
+func (c *Camera) ZoomAt(factor float32, screenX, screenY int) {
+	before := c.ScreenToWorld(screenX, screenY)
+	c.zoom = max(c.minZoom(), min(maxZoom, c.zoom*factor))
+	after := c.ScreenToWorld(screenX, screenY)
+	c.center = c.center.Add(before.Sub(after))
+	c.clamp()
+}
+
+func (c *Camera) Zoom() float32 {
+	return c.zoom
+}
+
+// minZoom is the zoom at which the world just fills the viewport.
+func (c *Camera) minZoom() float32 {
+	return max(c.viewW/c.worldW, c.viewH/c.worldH)
+}
+
+// clamp keeps the view inside the world.
+func (c *Camera) clamp() {
+	halfW := c.viewW / 2 / c.zoom
+	halfH := c.viewH / 2 / c.zoom
+	c.center.X = max(halfW, min(c.worldW-halfW, c.center.X))
+	c.center.Y = max(halfH, min(c.worldH-halfH, c.center.Y))
+}
+
+// GeoM returns the world to screen transform, including the screenshake.
+func (c *Camera) GeoM() ebiten.GeoM {
+	geom := c.geoM()
+	shake := c.trauma * c.trauma
+	if shake > 0 {
+		t := c.shakeTime * shakeFrequency
+		offsetX := maxShakeOffset * shake * noise(t, 0)
+		offsetY := maxShakeOffset * shake * noise(t, 10)
+		angle := maxShakeAngle * shake * noise(t, 20)
+
+		centerX := float64(c.viewX + c.viewW/2)
+		centerY := float64(c.viewY + c.viewH/2)
+		geom.Translate(-centerX, -centerY)
+		geom.Rotate(angle)
+		geom.Translate(centerX+offsetX, centerY+offsetY)
+	}
+	return geom
+}
+
+// geoM is the transform without shake, input must not shake along.
+func (c *Camera) geoM() ebiten.GeoM {
+	geom := ebiten.GeoM{}
+	geom.Translate(-float64(c.center.X), -float64(c.center.Y))
+	geom.Scale(float64(c.zoom), float64(c.zoom))
+	geom.Translate(float64(c.viewX+c.viewW/2), float64(c.viewY+c.viewH/2))
+	return geom
+}
+
+// InView reports whether a screen point lies inside the viewport.
+func (c *Camera) InView(screenX, screenY int) bool {
+	x, y := float32(screenX), float32(screenY)
+	return x >= c.viewX && x < c.viewX+c.viewW && y >= c.viewY && y < c.viewY+c.viewH
+}
+
+// ScreenToWorld converts a screen position, e.g. the cursor, to the world.
+func (c *Camera) ScreenToWorld(screenX, screenY int) lib.Vec2 {
+	geom := c.geoM()
+	geom.Invert()
+	x, y := geom.Apply(float64(screenX), float64(screenY))
+	return lib.NewVec2(float32(x), float32(y))
+}
+
+// WorldToScreen converts a world position to the screen, without shake.
+func (c *Camera) WorldToScreen(world lib.Vec2) (float64, float64) {
+	geom := c.geoM()
+	return geom.Apply(float64(world.X), float64(world.Y))
+}
+
+// noise is a cheap smooth pseudo random signal in the range -1 to 1.
+func noise(t, seed float64) float64 {
+	return (math.Sin(t+seed) + math.Sin(2.3*t+seed*1.7)) / 2
+}
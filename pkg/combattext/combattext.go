@@ -0,0 +1,108 @@
+// Package combattext shows short texts like damage numbers floating up from
+// where something happened in the world.
+package combattext
+
+import (
+	"image/color"
+	"jamegam/pkg/lib"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+type Style int
+
+const (
+	StyleDamage Style = iota
+	StyleMana
+)
+
+const (
+	lifetime   = 0.8
+	riseSpeed  = 60.0
+	fadeStart  = 0.5 // fraction of the lifetime after which texts fade out
+	maxScatter = 12.0
+)
+
+type floatingText struct {
+	text     string
+	style    Style
+	position lib.Vec2
+	life     float64
+}
+
+// Texts holds the live floating texts.
+type Texts struct {
+	texts *lib.FreeList[floatingText]
+}
+
+// Default holds the texts of the gameplay scene.
+var Default = New()
+
+func New() *Texts {
+	return &Texts{texts: lib.NewFreeList[floatingText](64)}
+}
+
+// Spawn shows a text floating up from the given world position.
+func (t *Texts) Spawn(at lib.Vec2, text string, style Style) {
+	scatter := lib.NewVec2((rand.Float32()-0.5)*2*maxScatter, 0)
+	t.texts.Insert(floatingText{
+		text:     text,
+		style:    style,
+		position: at.Add(scatter),
+	})
+}
+
+func (t *Texts) Update(dt float64) {
+	t.texts.FuncAll(func(idx int, ft floatingText) {
+		ft.life += dt
+		if ft.life >= lifetime {
+			t.texts.Remove(idx)
+			return
+		}
+		ft.position.Y -= float32(riseSpeed * dt)
+		t.texts.Set(idx, ft)
+	})
+}
+
+func (t *Texts) Draw(screen *ebiten.Image, face text.Face) {
+	t.texts.FuncAll(func(_ int, ft floatingText) {
+		alpha := float32(1)
+		if progress := ft.life / lifetime; progress > fadeStart {
+			alpha = float32(1 - (progress-fadeStart)/(1-fadeStart))
+		}
+
+		width, height := text.Measure(ft.text, face, 0)
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(ft.position.X)-width/2, float64(ft.position.Y)-height/2)
+
+		// a dark outline keeps the text readable on the map
+		op.ColorScale.ScaleWithColor(color.Black)
+		op.ColorScale.ScaleAlpha(alpha)
+		for _, offset := range [][2]float64{{-2, 0}, {2, 0}, {0, -2}, {0, 2}} {
+			op.GeoM.Translate(offset[0], offset[1])
+			text.Draw(screen, ft.text, face, op)
+			op.GeoM.Translate(-offset[0], -offset[1])
+		}
+
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(styleColor(ft.style))
+		op.ColorScale.ScaleAlpha(alpha)
+		text.Draw(screen, ft.text, face, op)
+	})
+}
+
+// Clear removes all texts.
+func (t *Texts) Clear() {
+	t.texts.Clear()
+}
+
+func styleColor(style Style) color.Color {
+	switch style {
+	case StyleMana:
+		return color.RGBA{255, 215, 60, 255}
+	default:
+		return color.RGBA{255, 255, 255, 255}
+	}
+}
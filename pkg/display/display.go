@@ -0,0 +1,64 @@
+// Package display maps the fixed logical resolution the game is drawn in onto
+// the window, which may have any size.
+package display
+
+import (
+	"jamegam/pkg/settings"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Width and Height are the logical resolution everything is drawn in.
+const (
+	Width  = 1024
+	Height = 1014
+)
+
+var (
+	screenW, screenH float64
+	scale            = 1.0
+	offsetX, offsetY float64
+)
+
+// Resize updates the mapping for a new screen size in device pixels. Must be
+// called from ebiten.Game.Layout.
+func Resize(width, height float64) {
+	screenW, screenH = width, height
+	scale = math.Min(width/Width, height/Height)
+	if settings.Current.IntegerScaling && scale >= 1 {
+		scale = math.Floor(scale)
+	}
+	offsetX = math.Floor((width - Width*scale) / 2)
+	offsetY = math.Floor((height - Height*scale) / 2)
+}
+
+// ScreenSize returns the screen size in device pixels.
+func ScreenSize() (int, int) {
+	return int(math.Ceil(screenW)), int(math.Ceil(screenH))
+}
+
+// Draw draws the logical screen centered onto the real one, letterboxed if
+// the aspect ratios differ.
+func Draw(screen, logical *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(offsetX, offsetY)
+	if scale != math.Floor(scale) {
+		op.Filter = ebiten.FilterLinear
+	}
+	screen.DrawImage(logical, op)
+}
+
+// CursorPosition returns the cursor position in logical coordinates, use it
+// instead of ebiten.CursorPosition for all hit tests.
+func CursorPosition() (int, int) {
+	x, y := ebiten.CursorPosition()
+	return ToLogical(x, y)
+}
+
+// ToLogical converts a screen position to logical coordinates.
+func ToLogical(x, y int) (int, int) {
+	return int(math.Floor((float64(x) - offsetX) / scale)),
+		int(math.Floor((float64(y) - offsetY) / scale))
+}
@@ -1,11 +1,11 @@
 package enemy
 
 import (
-	"image"
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
-	"jamegam/pkg/pauser"
+	"jamegam/pkg/i18n"
 	"log"
-	"time"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -16,8 +16,38 @@ const (
 	EnemyTypeBasic EnemyType = iota
 	EnemyTypeFast
 	EnemyTypeTank
+	EnemyTypePhantom
+	EnemyTypeBrute
 )
 
+// String returns the display name of the enemy type.
+func (t EnemyType) String() string {
+	return i18n.T("enemy." + t.key())
+}
+
+// key names the enemy type in message keys and the stats file.
+func (t EnemyType) key() string {
+	switch t {
+	case EnemyTypeBasic:
+		return "basic"
+	case EnemyTypeFast:
+		return "fast"
+	case EnemyTypeTank:
+		return "tank"
+	case EnemyTypePhantom:
+		return "phantom"
+	case EnemyTypeBrute:
+		return "brute"
+	}
+	return "unknown"
+}
+
+// Value returns the mana an enemy of the given type drops, which is also its
+// cost for the wave generator.
+func Value(enemyType EnemyType) int64 {
+	return stats[enemyType].Value
+}
+
 type Enemy struct {
 	enemyType    EnemyType
 	pathNodeLast int
@@ -29,19 +59,21 @@ type Enemy struct {
 	numPassedNodes float64 // The number of path nodes already passed, can be combined with pathProgress to get the exact total path progress
 
 	currentHealth   int
+	maxHealth       int
+	pendingDamage   int
 	currentSpeed    float32
 	currentSpeedMod float32
-	speedModEnd     time.Time
+	speedModLeft    float64 // seconds of game time
 
 	wander         float32 // the sideways wander from the path line
 	WanderVelocity float32
 	bounce         float32
 
-	spriteSheetTimer float32
-	spriteSheetIndex int
+	anim *animation.Animator
 
-	IsDead         bool
-	poofSheetIndex int
+	IsDead        bool
+	deathReported bool
+	HasLeaked     bool // the enemy reached the end of the path instead of being killed
 }
 
 func NewEnemy(enemyType EnemyType, pathNodeLast, pathNodeNext int, pathProgress float64) *Enemy {
@@ -53,69 +85,87 @@ func NewEnemy(enemyType EnemyType, pathNodeLast, pathNodeNext int, pathProgress
 		currentSpeedMod: 1,
 	}
 
-	switch enemyType {
-	case EnemyTypeBasic:
-		ret.currentHealth = 1
-		ret.currentSpeed = 1.6
-	case EnemyTypeFast:
-		ret.currentHealth = 2
-		ret.currentSpeed = 3
-	case EnemyTypeTank:
-		ret.currentHealth = 6
-		ret.currentSpeed = 1.1
-	default:
+	s, ok := stats[enemyType]
+	if !ok {
 		panic("Unknown enemy type")
 	}
+	ret.currentHealth = s.Health
+	ret.currentSpeed = s.Speed
+	ret.maxHealth = ret.currentHealth
+	ret.anim = animation.NewAnimator(nil)
+	ret.anim.Play(walkClip(enemyType))
 
 	return ret
 }
 
-func (e *Enemy) GetSprite() *ebiten.Image {
-	// i just hope this is called every frame once
-	fps := ebiten.ActualFPS()
-	dt := 1.0 / 60.0
-	if fps > 1/2000 {
-		dt = 1.0 / fps
+// ApplyScaling multiplies the base health and speed of the enemy, used by
+// endless mode to make later waves tougher.
+func (e *Enemy) ApplyScaling(healthMult, speedMult float64) {
+	e.currentHealth = int(math.Ceil(float64(e.currentHealth) * healthMult))
+	e.maxHealth = e.currentHealth
+	e.currentSpeed = e.currentSpeed * float32(speedMult)
+}
+
+// GetTint returns the color scale the enemy sprite should be drawn with.
+// Unlockable enemy types reuse the sheets of the basic ones with a tint.
+func (e *Enemy) GetTint() ebiten.ColorScale {
+	tint := ebiten.ColorScale{}
+	switch e.enemyType {
+	case EnemyTypePhantom:
+		tint.Scale(0.6, 0.6, 1.0, 0.75)
+	case EnemyTypeBrute:
+		tint.Scale(1.0, 0.45, 0.45, 1.0)
 	}
+	return tint
+}
 
+// walkClip returns the clip played while the enemy is alive. Unlockable
+// enemy types reuse the clips of the basic ones, see GetTint.
+func walkClip(enemyType EnemyType) *animation.Clip {
+	switch enemyType {
+	case EnemyTypeBasic:
+		return animation.Default.MustClip("rat_walk")
+	case EnemyTypeFast, EnemyTypePhantom:
+		return animation.Default.MustClip("bat_fly")
+	case EnemyTypeTank, EnemyTypeBrute:
+		return animation.Default.MustClip("zombie_walk")
+	}
+	log.Fatal("Unknown enemy type")
+	return nil
+}
+
+// Animate advances the sprite animation. Once the death animation has
+// finished, the destroy func is called.
+func (e *Enemy) Animate(dt float64) {
 	if e.IsDead {
-		e.spriteSheetTimer += float32(dt)
-		if e.spriteSheetTimer > 0.1 {
-			e.spriteSheetTimer = 0
-			e.spriteSheetIndex = (e.spriteSheetIndex + 1)
-		}
-		if e.spriteSheetIndex == 3 {
+		e.anim.Update(dt)
+		if e.anim.Finished() {
 			e.destroyFunc()
 		}
-		return SpriteEnemyPoofSheet.SubImage(image.Rect(e.spriteSheetIndex*16, 0, (e.spriteSheetIndex+1)*16, 16)).(*ebiten.Image)
-
+		return
 	}
 
-	if !pauser.IsPaused {
-		e.spriteSheetTimer += float32(dt) * (e.currentSpeed * 1.2)
-	}
-	if e.spriteSheetTimer > 0.1 {
-		e.spriteSheetTimer = 0
-		mod := 4
-		if e.enemyType == EnemyTypeFast {
-			mod = 5
-		}
-		e.spriteSheetIndex = (e.spriteSheetIndex + 1) % mod
-	}
+	// faster enemies move their legs faster
+	e.anim.Update(dt * float64(e.currentSpeed*1.2))
+}
 
-	switch e.enemyType {
-	case EnemyTypeBasic:
-		return SpriteEnemyBasicSheet.SubImage(image.Rect(e.spriteSheetIndex*16, 0, (e.spriteSheetIndex+1)*16, 16)).(*ebiten.Image)
-	case EnemyTypeFast:
-		// return SpriteEnemyFast
-		return SpriteEnemyFastSheet.SubImage(image.Rect(e.spriteSheetIndex*16, 0, (e.spriteSheetIndex+1)*16, 16)).(*ebiten.Image)
-	case EnemyTypeTank:
-		// return SpriteEnemyTank
-		return SpriteEnemyTankSheet.SubImage(image.Rect(e.spriteSheetIndex*16, 0, (e.spriteSheetIndex+1)*16, 16)).(*ebiten.Image)
+// IsBoss reports whether the enemy is a boss, which hits harder when it leaks.
+func (e *Enemy) IsBoss() bool {
+	return e.enemyType == EnemyTypeBrute
+}
+
+// TakeDeath reports whether the enemy died since the last call, so death
+// effects are only spawned once.
+func (e *Enemy) TakeDeath() bool {
+	if !e.IsDead || e.deathReported {
+		return false
 	}
+	e.deathReported = true
+	return true
+}
 
-	log.Fatal("Unknown enemy type")
-	return nil
+func (e *Enemy) GetSprite() *ebiten.Image {
+	return e.anim.Frame()
 }
 
 func (e *Enemy) GetWander() float32 {
@@ -152,12 +202,6 @@ func (e *Enemy) GetPathProgress() float64 {
 }
 
 func (e *Enemy) SetPathProgress(pathProgress float64) {
-	// we need to check time since we can't know when and how frequently
-	// SetPathProgress is called. but its called sometimes, which is good...
-	if e.speedModEnd.Before(time.Now()) {
-		e.currentSpeedMod = 1
-		e.speedModEnd = time.Time{}
-	}
 	e.pathProgress = pathProgress
 }
 
@@ -165,13 +209,27 @@ func (e *Enemy) GetHealth() int {
 	return e.currentHealth
 }
 
+func (e *Enemy) GetMaxHealth() int {
+	return e.maxHealth
+}
+
+// TakeDamage returns the damage taken since the last call, for showing damage
+// numbers.
+func (e *Enemy) TakeDamage() int {
+	damage := e.pendingDamage
+	e.pendingDamage = 0
+	return damage
+}
+
 func (e *Enemy) SetHealth(health int) {
+	if health < e.currentHealth && !e.IsDead {
+		e.pendingDamage += e.currentHealth - max(health, 0) // no overkill
+	}
 	e.currentHealth = health
-	if e.currentHealth <= 0 {
-		audio.Controller.Play("enemy_death_poof", 0.00)
+	if e.currentHealth <= 0 && !e.IsDead {
+		audio.Controller.Play("enemy_death_poof")
 		e.IsDead = true
-		e.spriteSheetIndex = 0
-		e.spriteSheetTimer = 0
+		e.anim.Play(animation.Default.MustClip("poof"))
 		e.currentSpeed = 0
 	}
 }
@@ -181,25 +239,28 @@ func (e *Enemy) GetSpeed() float32 {
 }
 
 func (e *Enemy) SetSpeedMod(speedMod float32, howLong float32) {
-	// This is criminal...
-	e.speedModEnd = time.Now().Add(time.Duration(howLong) * time.Second)
+	e.speedModLeft = float64(howLong)
 	e.currentSpeedMod = speedMod
 }
 
+// UpdateSpeedMod counts down the speed modifier and resets it once it ran
+// out. It has to be called every simulation step.
+func (e *Enemy) UpdateSpeedMod(dt float64) {
+	if e.speedModLeft <= 0 {
+		return
+	}
+	e.speedModLeft -= dt
+	if e.speedModLeft <= 0 {
+		e.currentSpeedMod = 1
+	}
+}
+
 func (e *Enemy) GetSpeedMod() float32 {
 	return e.currentSpeedMod
 }
 
 func (e *Enemy) GetValue() int64 {
-	switch e.enemyType {
-	case EnemyTypeBasic:
-		return 1
-	case EnemyTypeFast:
-		return 2
-	case EnemyTypeTank:
-		return 4
-	}
-	return 0
+	return Value(e.enemyType)
 }
 
 func (e *Enemy) GetNumPassedNodes() float64 {
@@ -0,0 +1,49 @@
+package enemy
+
+import (
+	"jamegam/pkg/audio"
+	"strings"
+	"testing"
+)
+
+func TestDeathPoofPlaysOnce(t *testing.T) {
+	previous := audio.Controller
+	t.Cleanup(func() { audio.Controller = previous })
+	silent := audio.NewSilentController()
+	audio.Controller = silent
+
+	e := NewEnemy(EnemyTypeFast, 0, 1, 0)
+	e.SetHealth(1)
+	if got := silent.PlayCount("enemy_death_poof"); got != 0 {
+		t.Fatalf("enemy_death_poof played %d times before the kill", got)
+	}
+	e.SetHealth(0)
+	e.SetHealth(-1) // hit again in the same step
+	if got := silent.PlayCount("enemy_death_poof"); got != 1 {
+		t.Errorf("enemy_death_poof played %d times, want 1", got)
+	}
+}
+
+func TestTakeDamageWithoutOverkill(t *testing.T) {
+	previous := audio.Controller
+	t.Cleanup(func() { audio.Controller = previous })
+	audio.Controller = audio.NewSilentController()
+
+	e := NewEnemy(EnemyTypeFast, 0, 1, 0)
+	health := e.GetHealth()
+	e.SetHealth(-100)
+	if got := e.TakeDamage(); got != health {
+		t.Errorf("TakeDamage() = %d, want the remaining health %d", got, health)
+	}
+}
+
+func TestParseStatsRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"basic": {"health": 1, "speed": 1, "value": 1}}`,
+		`{"basic": {"health": 1, "speed": 1, "value": 1, "armor": 2}}`,
+	} {
+		if _, err := parseStats(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package enemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+)
+
+// statsFile defines the stats of all enemy types, keyed by EnemyType.key.
+const statsFile = "enemies.json"
+
+// Stats are the base values of an enemy type, before endless mode scaling.
+type Stats struct {
+	Health int     `json:"health"`
+	Speed  float32 `json:"speed"` // path nodes per second
+	Value  int64   `json:"value"` // dropped mana and cost for the wave generator
+}
+
+var stats = map[EnemyType]Stats{}
+
+func init() {
+	lib.Must(loadStats())
+	assets.Watch(statsFile, loadStats)
+}
+
+// loadStats replaces the stats, enemies spawned afterwards use the new ones.
+func loadStats() error {
+	reader, err := assets.Open(statsFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	parsed, err := parseStats(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", statsFile, err)
+	}
+	stats = parsed
+	return nil
+}
+
+// parseStats reads a stats file, which has to define every enemy type.
+func parseStats(r io.Reader) (map[EnemyType]Stats, error) {
+	def := map[string]Stats{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, err
+	}
+	parsed := make(map[EnemyType]Stats)
+	for t := EnemyTypeBasic; t <= EnemyTypeBrute; t++ {
+		s, ok := def[t.key()]
+		if !ok {
+			return nil, fmt.Errorf("enemy %s is missing", t.key())
+		}
+		if s.Health <= 0 || s.Speed <= 0 {
+			return nil, fmt.Errorf("enemy %s needs health and speed", t.key())
+		}
+		parsed[t] = s
+		delete(def, t.key())
+	}
+	for key := range def {
+		return nil, fmt.Errorf("unknown enemy %q", key)
+	}
+	return parsed, nil
+}
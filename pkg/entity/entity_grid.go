@@ -1,30 +1,35 @@
 package entity
 
 import (
-	"bufio"
 	"fmt"
 	"image/color"
+	"jamegam/pkg/assets"
 
-	// "jamegam/pkg/audio"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/camera"
+	"jamegam/pkg/combattext"
 	"jamegam/pkg/enemy"
+	"jamegam/pkg/i18n"
 	"jamegam/pkg/lib"
-	"jamegam/pkg/pauser"
+	"jamegam/pkg/notify"
+	"jamegam/pkg/palette"
+	"jamegam/pkg/particles"
+	"jamegam/pkg/settings"
 	"jamegam/pkg/spatialhash"
 	"jamegam/pkg/sprites"
 	"jamegam/pkg/towers"
+	"jamegam/pkg/ui"
 	"log"
 	"math"
 	"math/rand"
-	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // Ensure EntityGrid implements Entity
 var _ Entity = &EntityGrid{}
+var _ WorldDrawer = &EntityGrid{}
 var _ towers.EnemyManager = &EntityGrid{}
 var _ towers.ProjectileManager = &EntityGrid{}
 
@@ -45,17 +50,12 @@ type EntityGrid struct {
 
 	Health int
 
-	messageTimer   float64
-	currentMessage string
-
 	towerRangeIndicator bool
 
-	textFace *text.GoTextFace
-
 	// Map & Path
-	mapDef    string
-	enemyPath []lib.Vec2I
-	mapTiles  [][]mapTileType
+	enemyPath    []lib.Vec2I
+	mapTiles     [][]mapTileType
+	levelVersion int // the version of the level in use, see setLevel
 
 	// Enemies and Towers
 	// enemies     []*enemy.Enemy // TODO: maybe use a free list here too
@@ -64,6 +64,9 @@ type EntityGrid struct {
 	towers        map[lib.Vec2I]towers.Tower
 	selectedTower lib.Vec2I // cant have pointers to towers because of map, so only cell
 	droppedMana   int64
+	enemiesKilled int64
+	enemiesLeaked int64
+	killsByTower  map[towers.TowerType]int64
 
 	// Projectiles
 	projectiles *lib.FreeList[towers.Projectile]
@@ -81,6 +84,20 @@ func (e *EntityGrid) AddMana(mana int64) {
 	e.droppedMana += mana
 }
 
+// RegisterKill implements towers.EnemyManager.
+func (e *EntityGrid) RegisterKill(source towers.TowerType) {
+	e.killsByTower[source]++
+}
+
+// EnemyCount returns the number of enemies currently on the map.
+func (e *EntityGrid) EnemyCount() int {
+	count := 0
+	e.enemies.FuncAll(func(_ int, _ *enemy.Enemy) {
+		count++
+	})
+	return count
+}
+
 // AddProjectile implements towers.ProjectileManager.
 func (e *EntityGrid) AddProjectile(projectile towers.Projectile) int {
 	return e.projectiles.Insert(projectile)
@@ -148,33 +165,28 @@ func NewEntityGrid(
 	xTiles int,
 	yTiles int,
 	tilePixels int,
-	mapDef string,
-	enemyPath []lib.Vec2I,
 ) *EntityGrid {
-	platformImage, _, err := ebitenutil.NewImageFromFile("test_platform.png")
+	platformImage, err := assets.Image("test_platform.png")
 	lib.Must(err)
-	floorImage, _, err := ebitenutil.NewImageFromFile("test_floor.png")
-	lib.Must(err)
-	arialFile, err := ebitenutil.OpenFile("font.ttf")
-	lib.Must(err)
-	textFaceSource, err := text.NewGoTextFaceSource(arialFile)
+	floorImage, err := assets.Image("test_floor.png")
 	lib.Must(err)
+	particles.Default.Clear() // leftovers of the last game
+	combattext.Default.Clear()
+	notify.Default.Clear()
 
 	newEnt := &EntityGrid{
 		xTiles:              xTiles,
 		yTiles:              yTiles,
 		tilePixels:          tilePixels,
-		mapDef:              mapDef,
-		enemyPath:           enemyPath,
 		projectiles:         lib.NewFreeList[towers.Projectile](2000),
 		enemies:             lib.NewFreeList[*enemy.Enemy](2000),
 		platformImage:       platformImage,
 		floorImage:          floorImage,
 		spatialHash:         spatialhash.NewSpatialHash(100_000, int32(tilePixels), 50_000),
-		textFace:            &text.GoTextFace{Source: textFaceSource, Size: 20},
 		towers:              make(map[lib.Vec2I]towers.Tower),
+		killsByTower:        make(map[towers.TowerType]int64),
 		droppedMana:         0,
-		towerRangeIndicator: true,
+		towerRangeIndicator: settings.Current.RangeIndicator,
 		selectedTower:       lib.NewVec2I(-1, -1),
 		Health:              100,
 	}
@@ -182,68 +194,83 @@ func NewEntityGrid(
 }
 
 func (e *EntityGrid) Init(EntitySpawner) {
-	scanner := bufio.NewScanner(strings.NewReader(e.mapDef))
-	rowCount := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		rowCount++
-		if len(line) != e.xTiles {
-			panic("Map definition is not the right size")
-		}
-		var row []mapTileType
-		for _, char := range line {
-			switch char {
-			case '.':
-				row = append(row, mapTileTypeEmpty)
-			case 'p':
-				row = append(row, mapTileTypePlatform)
-			}
-		}
-		e.mapTiles = append(e.mapTiles, row)
-	}
-	if rowCount != e.yTiles {
-		panic("Map definition is not the right size")
+	lib.Must(e.setLevel(level))
+}
+
+// setLevel swaps in the map and the path of the level. Enemies beyond the end
+// of a shorter path are moved onto its last segment.
+func (e *EntityGrid) setLevel(l *Level) error {
+	e.levelVersion = levelVersion
+	if len(l.Tiles) != e.yTiles || len(l.Tiles[0]) != e.xTiles {
+		return fmt.Errorf("the level has %dx%d tiles, want %dx%d", len(l.Tiles[0]), len(l.Tiles), e.xTiles, e.yTiles)
 	}
+	e.mapTiles = l.tiles()
+	e.enemyPath = l.path()
+	last := len(e.enemyPath) - 2
+	e.enemies.FuncAll(func(_ int, enem *enemy.Enemy) {
+		if _, next := enem.GetPathNodes(); next > last {
+			enem.SetPathNodes(last-1, last)
+		}
+	})
+	return nil
 }
 
-func (e *EntityGrid) SpawnEnemy(enType enemy.EnemyType) {
+func (e *EntityGrid) SpawnEnemy(enType enemy.EnemyType, healthMult, speedMult float64) {
 	enem := enemy.NewEnemy(enType, 0, 1, 0.0)
+	enem.ApplyScaling(healthMult, speedMult)
+	if enType == enemy.EnemyTypeBrute {
+		// Brutes are the boss enemies and get their own music cue.
+		audio.Controller.PlayStinger(audio.StingerBoss)
+	}
 	enValue := enem.GetValue()
 	idx := e.enemies.Insert(enem)
 	enem.SetDestroyFunc(func() {
 		e.enemies.Remove(idx)
 		e.droppedMana += enValue
+		if !enem.HasLeaked {
+			e.enemiesKilled++
+		}
 	})
 }
 
-func (e *EntityGrid) ShowMessage(message string) {
-	// audio.Controller.Play("error", 0.0) // TODO: notification sound?
-	e.currentMessage = "> " + message
-	e.messageTimer = 3.0
-}
-
 func (e *EntityGrid) Update(EntitySpawner) error {
 
+	// The game is over, the inventory takes care of showing the score.
 	if e.Health <= 0 {
-		e.Restart()
 		return nil
 	}
 
-	e.messageTimer -= lib.Dt()
-	if e.messageTimer <= 0 {
-		e.messageTimer = -2
-		e.currentMessage = ""
+	if e.levelVersion != levelVersion {
+		if err := e.setLevel(level); err != nil {
+			log.Printf("Could not swap in the changed level: %v", err)
+		}
 	}
 
-	e.spatialHash.Clear()
+	// Fast-forward runs several steps per tick instead of one large one, so
+	// the spatial hash and projectile hits stay as precise as at normal speed.
+	for range lib.SubSteps() {
+		if e.Health <= 0 {
+			break
+		}
+		e.step(lib.StepDt())
+	}
+
+	particles.Default.Update(lib.SimDt())
+	combattext.Default.Update(lib.SimDt())
 
-	dt := lib.Dt()
+	return nil
+}
+
+// step advances enemies, towers and projectiles by dt seconds of game time.
+func (e *EntityGrid) step(dt float64) {
+	e.spatialHash.Clear()
 
 	// Move Enemies
 	shElements := []*spatialhash.SHElement{}
 	// for idx, enemy := range e.enemies {
 	killedEnemies := []int{}
 	e.enemies.FuncAll(func(idx int, enemy *enemy.Enemy) {
+		enemy.UpdateSpeedMod(dt)
 		lastIdx, nextIdx := enemy.GetPathNodes()
 		progress := enemy.GetPathProgress()
 		progress += float64(enemy.GetSpeed()) * dt
@@ -255,6 +282,12 @@ func (e *EntityGrid) Update(EntitySpawner) error {
 				killedEnemies = append(killedEnemies, idx)
 				log.Println("Enemy reached the end")
 				e.Health--
+				e.enemiesLeaked++
+				if enemy.IsBoss() {
+					camera.Main.AddTrauma(0.7)
+				} else {
+					camera.Main.AddTrauma(0.35)
+				}
 
 			}
 			enemy.SetPathNodes(lastIdx+1, nextIdx+1)
@@ -274,7 +307,9 @@ func (e *EntityGrid) Update(EntitySpawner) error {
 	})
 
 	for _, idx := range killedEnemies {
-		e.enemies.Get(idx).SetHealth(0)
+		enem := e.enemies.Get(idx)
+		enem.HasLeaked = true
+		enem.SetHealth(0)
 	}
 
 	e.spatialHash.Construct(shElements)
@@ -289,14 +324,40 @@ func (e *EntityGrid) Update(EntitySpawner) error {
 		projectile.Update(e, e)
 	})
 
-	return nil
+	// Animate Enemies
+	e.enemies.FuncAll(func(_ int, enem *enemy.Enemy) {
+		newWander := enem.GetWander() + float32(dt)*enem.WanderVelocity
+		// damped by 5% per 1/60 s, whatever the step size
+		enem.WanderVelocity = enem.WanderVelocity*float32(math.Pow(0.95, dt*60)) + (rand.Float32()-0.5)*200*float32(dt)
+		enem.SetWander(max(-10, min(10, newWander)))
+		enem.SetBounce(enem.GetBounce() + float32(dt)*float32(math.Sqrt(float64(enem.GetSpeed())))*10)
+		if damage := enem.TakeDamage(); damage > 0 && !enem.HasLeaked {
+			at := e.enemyCenter(enem).Sub(lib.NewVec2(0, float32(e.tilePixels)/2))
+			combattext.Default.Spawn(at, fmt.Sprint(damage), combattext.StyleDamage)
+		}
+		if enem.TakeDeath() && !enem.HasLeaked {
+			particles.Default.Burst(particles.Death, e.enemyCenter(enem))
+		}
+		enem.Animate(dt)
+	})
+}
+
+// enemyCenter returns the center of an enemy on screen, ignoring wander and
+// bounce.
+func (e *EntityGrid) enemyCenter(enem *enemy.Enemy) lib.Vec2 {
+	lastIdx, nextIdx := enem.GetPathNodes()
+	last := e.enemyPath[lastIdx].ToVec2().Mul(float32(e.tilePixels))
+	next := e.enemyPath[nextIdx].ToVec2().Mul(float32(e.tilePixels))
+	half := float32(e.tilePixels) / 2
+	return last.Lerp(next, float32(enem.GetPathProgress())).Add(lib.NewVec2(half, half))
 }
 
 func (e *EntityGrid) Deinit(EntitySpawner) {
 
 }
 
-func (e *EntityGrid) Draw(screen *ebiten.Image) {
+// DrawWorld implements WorldDrawer.
+func (e *EntityGrid) DrawWorld(screen *ebiten.Image) {
 	// Draw Grid
 	geom := ebiten.GeoM{}
 	geom.Scale(4, 4)
@@ -351,26 +412,18 @@ func (e *EntityGrid) Draw(screen *ebiten.Image) {
 		next := e.enemyPath[nextIdx].ToVec2().Mul(float32(e.tilePixels))
 		pos := last.Lerp(next, float32(progress))
 
-		newWander := enem.GetWander() + float32(lib.Dt())*enem.WanderVelocity
-		enem.WanderVelocity = enem.WanderVelocity*0.95 + (rand.Float32()-0.5)*200*float32(lib.Dt())
-		newWander = max(-10, min(10, newWander))
-		if !pauser.IsPaused {
-			enem.SetWander(newWander)
-		}
 		wanderDirection := next.Sub(last).Normalize().Rotate(90).Mul(enem.GetWander())
 
-		newBounce := enem.GetBounce() + float32(lib.Dt())*float32(math.Sqrt(float64(enem.GetSpeed())))*10
-		if !pauser.IsPaused {
-			enem.SetBounce(newBounce)
-		}
-
 		geom := ebiten.GeoM{}
 		geom.Scale(4, 4)
 		geom.Translate(float64(pos.X), float64(pos.Y))
-		geom.Translate(float64(wanderDirection.X), float64(wanderDirection.Y))
-		geom.Translate(0, math.Sin(float64(newBounce))*5)
+		if !settings.Current.ReducedMotion {
+			geom.Translate(float64(wanderDirection.X), float64(wanderDirection.Y))
+			geom.Translate(0, math.Sin(float64(enem.GetBounce()))*5)
+		}
 		screen.DrawImage(enem.GetSprite(), &ebiten.DrawImageOptions{
-			GeoM: geom,
+			GeoM:       geom,
+			ColorScale: enem.GetTint(),
 		})
 		if enem.GetSpeedMod() < 1.0 {
 			// Draw a slow effect
@@ -379,6 +432,11 @@ func (e *EntityGrid) Draw(screen *ebiten.Image) {
 			})
 		}
 
+		if settings.Current.HealthBars && !enem.IsDead && enem.GetHealth() < enem.GetMaxHealth() {
+			barX, barY := geom.Apply(8, -2)
+			drawHealthBar(screen, float32(barX), float32(barY), float32(enem.GetHealth())/float32(enem.GetMaxHealth()))
+		}
+
 		// Draw Hitbox
 		// vector.StrokeCircle(screen,
 		// 	float32(pos.X)+32,
@@ -402,14 +460,8 @@ func (e *EntityGrid) Draw(screen *ebiten.Image) {
 	}
 	if e.selectedTower.X >= 0 && e.selectedTower.Y >= 0 {
 		selectedTower := e.towers[e.selectedTower]
-		if selectedTower != nil {
-			radius := selectedTower.Radius()
-			vector.DrawFilledCircle(screen,
-				float32(e.selectedTower.X*64+32),
-				float32(e.selectedTower.Y*64+32),
-				radius,
-				color.RGBA{0, 0, 0, 80},
-				false)
+		if selectedTower != nil && e.towerRangeIndicator {
+			drawRange(screen, float32(e.selectedTower.X*64+32), float32(e.selectedTower.Y*64+32), selectedTower.Radius())
 			selectedTower.Draw(screen) // draw that one again on top
 		}
 	}
@@ -419,15 +471,39 @@ func (e *EntityGrid) Draw(screen *ebiten.Image) {
 		projectile.Draw(screen)
 	})
 
-	// Draw Message
-	if e.messageTimer > -0.5 {
-		geom := ebiten.GeoM{}
-		geom.Translate(10, (12*64)-34)
-		vector.DrawFilledRect(screen, 5, 12*64-39, 16*64-10, 34, color.RGBA{0, 0, 0, 160}, false)
-		text.Draw(screen, fmt.Sprintf("%v", e.currentMessage), e.textFace, &text.DrawOptions{
-			DrawImageOptions: ebiten.DrawImageOptions{GeoM: geom},
-		})
+	particles.Default.Draw(screen)
+	combattext.Default.Draw(screen, i18n.Face(ui.ScaledFontSize()))
+}
+
+func (e *EntityGrid) Draw(screen *ebiten.Image) {
+	notify.Default.Draw(screen, i18n.Face(ui.ScaledFontSize()), 5, 12*64-5, 16*64-10)
+}
+
+// drawRange draws the range of a tower around its center. In high contrast
+// mode it gets a bright ring with a dark outline, which is visible on any
+// part of the map.
+func drawRange(screen *ebiten.Image, x, y, radius float32) {
+	if !settings.Current.HighContrast {
+		vector.DrawFilledCircle(screen, x, y, radius, color.RGBA{0, 0, 0, 80}, false)
+		return
+	}
+	vector.DrawFilledCircle(screen, x, y, radius, color.RGBA{255, 255, 255, 50}, false)
+	vector.StrokeCircle(screen, x, y, radius, 6, palette.Outline, false)
+	vector.StrokeCircle(screen, x, y, radius, 3, color.White, false)
+}
+
+// drawHealthBar draws a small health bar centered on x.
+func drawHealthBar(screen *ebiten.Image, x, y, fraction float32) {
+	const width, height = 40, 6
+	left := x - width/2
+	vector.DrawFilledRect(screen, left-1, y-1, width+2, height+2, color.RGBA{0, 0, 0, 200}, false)
+	barColor := palette.Good()
+	if fraction < 0.35 {
+		barColor = palette.Bad()
+	} else if fraction < 0.65 {
+		barColor = palette.Warning()
 	}
+	vector.DrawFilledRect(screen, left, y, width*max(0, fraction), height, barColor, false)
 }
 
 func drawGridLine(screen *ebiten.Image, x, y, tilePixels int) {
@@ -451,9 +527,18 @@ func drawGridLine(screen *ebiten.Image, x, y, tilePixels int) {
 }
 
 func (e *EntityGrid) Restart() {
-	e.NukeEnemies()
+	// Clear instead of nuking, dying enemies would otherwise still drop mana
+	// and count as kills in the new run.
+	e.enemies.Clear()
+	e.droppedMana = 0
 	e.projectiles.Clear()
+	particles.Default.Clear()
+	combattext.Default.Clear()
+	notify.Default.Clear()
 	e.selectedTower = lib.NewVec2I(-1, -1)
 	e.towers = make(map[lib.Vec2I]towers.Tower)
 	e.Health = 100
+	e.enemiesKilled = 0
+	e.enemiesLeaked = 0
+	e.killsByTower = make(map[towers.TowerType]int64)
 }
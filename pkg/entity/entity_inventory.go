@@ -1,25 +1,31 @@
 package entity
 
 import (
-	"fmt"
-	"image"
-	"image/color"
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
+	"jamegam/pkg/camera"
+	"jamegam/pkg/display"
 	"jamegam/pkg/enemy"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/notify"
+	"jamegam/pkg/palette"
+	"jamegam/pkg/settings"
 	"jamegam/pkg/towers"
+	"jamegam/pkg/ui"
 	"jamegam/pkg/wave_controller"
+	"math"
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // Ensure EntityInventory implements Entity
 var _ Entity = &EntityInventory{}
+var _ WorldDrawer = &EntityInventory{}
 
 type ItemRarity int64
 
@@ -52,6 +58,38 @@ const (
 	SpeedBuffMedium
 )
 
+const (
+	// prepPhaseDuration is the time in seconds between two waves.
+	prepPhaseDuration = 30.0
+	// earlyStartBonusPerSecond is the currency awarded for every second of
+	// prep phase skipped by starting the next wave early.
+	earlyStartBonusPerSecond = 5.0
+	// maxFastForward is the fastest fast-forward speed.
+	maxFastForward = 3
+)
+
+// Score summarizes a run and is shown on the game over screen.
+type Score struct {
+	WavesSurvived  int64
+	EnemiesKilled  int64
+	CurrencyEarned int64
+}
+
+// Total condenses the score into a single number.
+func (s Score) Total() int64 {
+	return s.WavesSurvived*100 + s.EnemiesKilled*10 + s.CurrencyEarned/10
+}
+
+// RunStats holds the statistics of a run, shown on the game over and victory
+// screens.
+type RunStats struct {
+	Score
+	KillsByTower  map[towers.TowerType]int64
+	EnemiesLeaked int64
+	CurrencySpent int64
+	Endless       bool
+}
+
 type EntityInventory struct {
 	inventory           [4]Item
 	selectedItem        int
@@ -59,12 +97,18 @@ type EntityInventory struct {
 	waveController      *wavecontroller.WaveController
 	currentWave         []enemy.EnemyType
 	peace               bool
+	prepTimer           float64
 	enemySpawnTimer     float64
 	waveCounter         int64
+	victoryWave         int64 // surviving this many waves wins the map, 0 means no victory
+	gameOver            bool
+	victory             bool
 	freeTurretSelected  towers.TowerType
 	freeUpgradeSelected bool
 	maxUpgradeSelected  bool
 
+	fastForward int // multiplies the game speed, 1 to maxFastForward
+
 	speedBoostActive    int
 	speedBoostDuration  float32
 	damageBoostActive   int
@@ -83,47 +127,29 @@ type EntityInventory struct {
 
 	// Currency
 	currentCurrency int64
+	currencyEarned  int64
+	currencySpent   int64
 
-	// Tower Buttons
-	basicTowerButton  lib.Vec2I
-	tackTowerButton   lib.Vec2I
-	iceTowerButton    lib.Vec2I
-	aoeTowerButton    lib.Vec2I
-	superTowerButton  lib.Vec2I
 	blueprintSelected towers.TowerType
 
-	// Menu Buttons
-	playButton     lib.Vec2I
-	removeButton   lib.Vec2I
-	damageButton   lib.Vec2I
-	firerateButton lib.Vec2I
+	// Widgets
+	ui             *ui.Root
+	upgradeButtons [towers.BranchCount]*ui.IconButton
+	towerPanel     *ui.Panel
+	barWidgets     map[lib.Vec2I]ui.Widget // bar cells reachable by the cursor
+
+	// Keyboard and gamepad cursor, rows below the map are the bar.
+	cursor       lib.Vec2I
+	cursorActive bool
+	lastMouse    lib.Vec2I
 
 	// Resources
-	inventorySlotImage    *ebiten.Image
-	basicTowerImage       *ebiten.Image
-	tackTowerImage        *ebiten.Image
-	iceTowerImage         *ebiten.Image
-	aoeTowerImage         *ebiten.Image
-	superTowerImage       *ebiten.Image
-	cashTowerImage        *ebiten.Image
-	hatImage              *ebiten.Image
-	textFace              *text.GoTextFace
-	playButtonImage       *ebiten.Image
-	removeButtonImage     *ebiten.Image
-	damageButtonImage     *ebiten.Image
-	firerateButtonImage   *ebiten.Image
-	inventoryBarImage     *ebiten.Image
-	upgradeIndicatorImage *ebiten.Image
-	freeUpgradeImage      *ebiten.Image
-	maxUpgradeImage       *ebiten.Image
-	bombImage             *ebiten.Image
-	speedSmallImage       *ebiten.Image
-	speedMediumImage      *ebiten.Image
-	damageSmallImage      *ebiten.Image
-	damageMediumImage     *ebiten.Image
-	dollarImage           *ebiten.Image
-	dollarOrangeImage     *ebiten.Image
-	dollarRedImage        *ebiten.Image
+	basicTowerImage *ebiten.Image
+	tackTowerImage  *ebiten.Image
+	iceTowerImage   *ebiten.Image
+	aoeTowerImage   *ebiten.Image
+	superTowerImage *ebiten.Image
+	cashTowerImage  *ebiten.Image
 }
 
 func isInBounds(vect lib.Vec2I) bool {
@@ -140,118 +166,47 @@ func (e *EntityInventory) isOnPath(vect lib.Vec2I) bool {
 }
 
 func NewEntityInventory(tilePixels int, grid *EntityGrid) *EntityInventory {
-	inventorySlotImage, _, err := ebitenutil.NewImageFromFile("inventory_slot.png")
-	lib.Must(err)
-
-	basicTowerImage := towers.SpritesheetTowerBasic.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-	tackTowerImage := towers.SpritesheetTowerTacks.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-	iceTowerImage := towers.SpritesheetTowerIce.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-	aoeTowerImage := towers.SpritesheetTowerAoe.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-	cashTowerImage := towers.SpritesheetTowerCash.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-	superTowerImage := towers.SpritesheetTowerSuper.SubImage(image.Rect(0, 0, 16, 16)).(*ebiten.Image)
-
-	freeUpgradeImage, _, err := ebitenutil.NewImageFromFile("freeUpgrade.png")
-	lib.Must(err)
-	maxUpgradeImage, _, err := ebitenutil.NewImageFromFile("maxUpgrade.png")
-	lib.Must(err)
-	bombImage, _, err := ebitenutil.NewImageFromFile("bomb.png")
-	lib.Must(err)
-
-	speedSmallImage, _, err := ebitenutil.NewImageFromFile("speedSmall.png")
-	lib.Must(err)
-	speedMediumImage, _, err := ebitenutil.NewImageFromFile("speedMedium.png")
-	lib.Must(err)
-
-	damageSmallImage, _, err := ebitenutil.NewImageFromFile("damageSmall.png")
-	lib.Must(err)
-	damageMediumImage, _, err := ebitenutil.NewImageFromFile("damageMedium.png")
-	lib.Must(err)
-
-	dollarImage, _, err := ebitenutil.NewImageFromFile("dollar.png")
-	lib.Must(err)
-	dollarOrangeImage, _, err := ebitenutil.NewImageFromFile("dollarOrange.png")
-	lib.Must(err)
-	dollarRedImage, _, err := ebitenutil.NewImageFromFile("dollarRed.png")
-	lib.Must(err)
-
-	hatImage, _, err := ebitenutil.NewImageFromFile("test_hat.png")
-	lib.Must(err)
-	arialFile, err := ebitenutil.OpenFile("font.ttf")
-	lib.Must(err)
-	textFaceSource, err := text.NewGoTextFaceSource(arialFile)
-	lib.Must(err)
-	inventoryBarImage, _, err := ebitenutil.NewImageFromFile("menu_bar_1024x246.png")
-	lib.Must(err)
-	playButtonImage, _, err := ebitenutil.NewImageFromFile("test_playbutton.png")
-	lib.Must(err)
-	removeButtonImage, _, err := ebitenutil.NewImageFromFile("test_removebutton.png")
-	lib.Must(err)
-	damageButtonImage, _, err := ebitenutil.NewImageFromFile("test_damagebutton.png")
-	lib.Must(err)
-	firerateButtonImage, _, err := ebitenutil.NewImageFromFile("test_ammobutton.png")
-	lib.Must(err)
-	upgradeIndicatorImage, _, err := ebitenutil.NewImageFromFile("upgradeindicator.png")
-	lib.Must(err)
+	basicTowerImage := animation.Default.MustClip("tower_basic").Frame(0)
+	tackTowerImage := animation.Default.MustClip("tower_tacks").Frame(0)
+	iceTowerImage := animation.Default.MustClip("tower_ice").Frame(0)
+	aoeTowerImage := animation.Default.MustClip("tower_aoe").Frame(0)
+	cashTowerImage := animation.Default.MustClip("tower_cash").Frame(0)
+	superTowerImage := animation.Default.MustClip("tower_super").Frame(0)
 
 	newEnt := &EntityInventory{
-		tilePixels:            tilePixels,
-		buttonPixels:          96,
-		inventorySlotImage:    inventorySlotImage,
-		basicTowerImage:       basicTowerImage,
-		tackTowerImage:        tackTowerImage,
-		iceTowerImage:         iceTowerImage,
-		aoeTowerImage:         aoeTowerImage,
-		cashTowerImage:        cashTowerImage,
-		superTowerImage:       superTowerImage,
-		hatImage:              hatImage,
-		inventory:             [4]Item{ManaTower, NoItem, NoItem, NoItem},
-		selectedItem:          -1,
-		damageBoostActive:     0,
-		speedBoostActive:      0,
-		damageBoostDuration:   0,
-		speedBoostDuration:    0,
-		grid:                  grid,
-		hoveredTileHasTower:   false,
-		hoveredTileIsOnPath:   false,
-		blueprintSelected:     0,
-		currentMana:           0,
-		maximumMana:           500,
-		textFace:              &text.GoTextFace{Source: textFaceSource, Size: 20},
-		waveController:        wavecontroller.NewWaveController(100),
-		peace:                 true,
-		enemySpawnTimer:       0.0,
-		currentCurrency:       500, // TODO: balance this
-		waveCounter:           0,
-		turretRangeIndicator:  true,
-		freeTurretSelected:    towers.TowerTypeNone,
-		freeUpgradeSelected:   false,
-		maxUpgradeSelected:    false,
-		inventoryBarImage:     inventoryBarImage,
-		playButtonImage:       playButtonImage,
-		removeButtonImage:     removeButtonImage,
-		damageButtonImage:     damageButtonImage,
-		firerateButtonImage:   firerateButtonImage,
-		upgradeIndicatorImage: upgradeIndicatorImage,
-		freeUpgradeImage:      freeUpgradeImage,
-		maxUpgradeImage:       maxUpgradeImage,
-		bombImage:             bombImage,
-		speedSmallImage:       speedSmallImage,
-		speedMediumImage:      speedMediumImage,
-		damageSmallImage:      damageSmallImage,
-		damageMediumImage:     damageMediumImage,
-		dollarImage:           dollarImage,
-		dollarOrangeImage:     dollarOrangeImage,
-		dollarRedImage:        dollarRedImage,
-		basicTowerButton:      lib.NewVec2I(2, 1),
-		tackTowerButton:       lib.NewVec2I(3, 1),
-		iceTowerButton:        lib.NewVec2I(4, 1),
-		aoeTowerButton:        lib.NewVec2I(5, 1),
-		superTowerButton:      lib.NewVec2I(6, 1),
-		playButton:            lib.NewVec2I(0, 0),
-		removeButton:          lib.NewVec2I(1, 0),
-		damageButton:          lib.NewVec2I(2, 0),
-		firerateButton:        lib.NewVec2I(3, 0),
-	}
+		tilePixels:           tilePixels,
+		buttonPixels:         96,
+		basicTowerImage:      basicTowerImage,
+		tackTowerImage:       tackTowerImage,
+		iceTowerImage:        iceTowerImage,
+		aoeTowerImage:        aoeTowerImage,
+		cashTowerImage:       cashTowerImage,
+		superTowerImage:      superTowerImage,
+		inventory:            [4]Item{ManaTower, NoItem, NoItem, NoItem},
+		selectedItem:         -1,
+		damageBoostActive:    0,
+		speedBoostActive:     0,
+		damageBoostDuration:  0,
+		speedBoostDuration:   0,
+		grid:                 grid,
+		hoveredTileHasTower:  false,
+		hoveredTileIsOnPath:  false,
+		blueprintSelected:    0,
+		currentMana:          0,
+		maximumMana:          500,
+		waveController:       wavecontroller.NewWaveController(100),
+		peace:                true,
+		prepTimer:            prepPhaseDuration,
+		enemySpawnTimer:      0.0,
+		fastForward:          1,
+		currentCurrency:      500, // TODO: balance this
+		waveCounter:          0,
+		turretRangeIndicator: settings.Current.RangeIndicator,
+		freeTurretSelected:   towers.TowerTypeNone,
+		freeUpgradeSelected:  false,
+		maxUpgradeSelected:   false,
+	}
+	newEnt.buildUI()
 	return newEnt
 }
 
@@ -259,30 +214,44 @@ func (e *EntityInventory) Init(EntitySpawner) {
 }
 
 func (e *EntityInventory) Update(EntitySpawner) error {
-	// Restart Game
+	// Game Over and Victory
 	if e.grid.Health <= 0 {
-		e.RestartGame()
+		e.gameOver = true
+	}
+	if e.victoryWave > 0 && e.waveCounter >= e.victoryWave && e.peace && e.grid.EnemyCount() == 0 {
+		e.victory = true
+	}
+	if e.gameOver || e.victory {
+		return nil
 	}
 
-	mouseX, mouseY := ebiten.CursorPosition()
+	mouseX, mouseY := display.CursorPosition()
 
 	if e.peace {
 		e.enemySpawnTimer = 0.0
+		if !e.isFinalWaveReached() {
+			e.prepTimer -= lib.SimDt()
+		}
+		if e.prepTimer <= 0 {
+			e.StartWave()
+		}
 	} else {
-		dt := lib.Dt()
+		dt := lib.SimDt()
 		e.enemySpawnTimer += dt
 		if len(e.currentWave) > 0 {
 			if e.enemySpawnTimer > 0.8 {
 				e.enemySpawnTimer = (rand.Float64() - 0.5) * 0.7
-				e.grid.SpawnEnemy(e.currentWave[0])
+				healthMult, speedMult := e.waveController.EnemyModifiers()
+				e.grid.SpawnEnemy(e.currentWave[0], healthMult, speedMult)
 				e.currentWave = e.currentWave[1:]
 			}
 		} else {
 			e.peace = true
+			e.prepTimer = prepPhaseDuration
 		}
 	}
 
-	dt := lib.Dt()
+	dt := lib.SimDt()
 	if e.speedBoostActive != 0 && !e.peace {
 		e.speedBoostDuration -= float32(dt)
 		if e.speedBoostDuration <= 0 {
@@ -298,99 +267,46 @@ func (e *EntityInventory) Update(EntitySpawner) error {
 
 	}
 
+	// Music follows the wave state
+	if e.peace {
+		audio.Controller.SetMusicState(audio.MusicCalm)
+	} else {
+		audio.Controller.SetMusicState(audio.MusicIntense)
+	}
+
 	e.currentMana += e.grid.droppedMana
 	e.grid.droppedMana = 0
 
-	// Start Wave Button and Hotkey
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && isInButton(mouseX, mouseY, e.getButtonPosition(e.playButton)) {
-		audio.Controller.Play("click", 0.00)
-		// TODO: horn sound maybe
-		e.StartWave()
-	} else if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		audio.Controller.Play("click", 0.00)
-		// TODO: horn sound maybe
-		e.StartWave()
-	}
+	// Buttons and their hotkeys
+	e.towerPanel.Hidden = !e.isTowerSelected()
+	e.updateCursor()
+	overUI := e.ui.Update(lib.Dt())
 
 	// Toggle Turret Range Indicators
-	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
-		audio.Controller.Play("click", 0.00)
+	if input.JustPressed(input.ActionToggleRange) {
+		audio.Controller.PlayUI("click")
 		e.ToggleTowerIndicator()
 	}
 
-	// Hat Button
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && e.isInHatButton(mouseX, mouseY) {
-		audio.Controller.Play("click", 0.00)
-		// TODO: other hat sounds
-		e.ActivateHat()
-	}
-
-	// Item Buttons
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		if isInButton(mouseX, mouseY, e.getButtonPosition(lib.NewVec2I(5, 0))) {
-			audio.Controller.Play("click", 0.00)
-			e.ActivateItem(0)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(lib.NewVec2I(6, 0))) {
-			audio.Controller.Play("click", 0.00)
-			e.ActivateItem(1)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(lib.NewVec2I(7, 0))) {
-			audio.Controller.Play("click", 0.00)
-			e.ActivateItem(2)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(lib.NewVec2I(8, 0))) {
-			audio.Controller.Play("click", 0.00)
-			e.ActivateItem(3)
-		}
-	}
-
-	// Tower Buttons
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		if isInButton(mouseX, mouseY, e.getButtonPosition(e.basicTowerButton)) {
-			audio.Controller.Play("click", 0.00)
-			e.grid.ShowMessage(fmt.Sprintf("Cost: 100"))
-			e.selectTowerType(towers.TowerTypeBasic)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(e.tackTowerButton)) {
-			audio.Controller.Play("click", 0.00)
-			e.grid.ShowMessage(fmt.Sprintf("Cost: 100"))
-			e.selectTowerType(towers.TowerTypeTacks)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(e.iceTowerButton)) {
-			audio.Controller.Play("click", 0.00)
-			e.grid.ShowMessage(fmt.Sprintf("Cost: 100"))
-			e.selectTowerType(towers.TowerTypeIce)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(e.aoeTowerButton)) {
-			audio.Controller.Play("click", 0.00)
-			e.grid.ShowMessage(fmt.Sprintf("Cost: 100"))
-			e.selectTowerType(towers.TowerTypeAoe)
-		} else if isInButton(mouseX, mouseY, e.getButtonPosition(e.superTowerButton)) {
-			audio.Controller.Play("click", 0.00)
-			e.grid.ShowMessage(fmt.Sprintf("Cost: 100"))
-			e.selectTowerType(towers.TowerTypeSuper)
+	// Tower Placement
+	e.hoveredTile = lib.NewVec2I(-1, -1)
+	clicked := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	if e.cursorActive {
+		if e.cursorInWorld() {
+			e.hoveredTile = e.cursor
+			clicked = clicked || input.JustPressed(input.ActionConfirm)
 		}
+	} else if !overUI && camera.Main.InView(mouseX, mouseY) {
+		world := camera.Main.ScreenToWorld(mouseX, mouseY)
+		e.hoveredTile = lib.NewVec2I(
+			int(math.Floor(float64(world.X)/float64(e.tilePixels))),
+			int(math.Floor(float64(world.Y)/float64(e.tilePixels))),
+		)
 	}
-
-	// Tower Hotkeys
-	if inpututil.IsKeyJustPressed(ebiten.Key1) {
-		audio.Controller.Play("click", 0.00)
-		e.selectTowerType(towers.TowerTypeBasic)
-	} else if inpututil.IsKeyJustPressed(ebiten.Key2) {
-		audio.Controller.Play("click", 0.00)
-		e.selectTowerType(towers.TowerTypeTacks)
-	} else if inpututil.IsKeyJustPressed(ebiten.Key3) {
-		audio.Controller.Play("click", 0.00)
-		e.selectTowerType(towers.TowerTypeIce)
-	} else if inpututil.IsKeyJustPressed(ebiten.Key4) {
-		audio.Controller.Play("click", 0.00)
-		e.selectTowerType(towers.TowerTypeAoe)
-	} else if inpututil.IsKeyJustPressed(ebiten.Key5) {
-		audio.Controller.Play("click", 0.00)
-		e.selectTowerType(towers.TowerTypeSuper)
-	}
-
-	// Tower Placement
-	e.hoveredTile = lib.NewVec2I(mouseX/e.tilePixels, mouseY/e.tilePixels)
 	e.hoveredTileIsOnPath = e.isOnPath(e.hoveredTile)
 	_, e.hoveredTileHasTower = e.grid.towers[e.hoveredTile]
 	if (e.blueprintSelected != towers.TowerTypeNone || e.freeTurretSelected != towers.TowerTypeNone) && isInBounds(e.hoveredTile) && !e.hoveredTileIsOnPath && !e.hoveredTileHasTower {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if clicked {
 			selectedTowerType := towers.TowerTypeBasic
 			free := false
 			if e.blueprintSelected != towers.TowerTypeNone {
@@ -415,26 +331,25 @@ func (e *EntityInventory) Update(EntitySpawner) error {
 				tower = towers.NewTowerCash(e.hoveredTile.Mul(e.tilePixels))
 			}
 			if tower != nil {
-				audio.Controller.Play("build_tower", 0.10)
+				audio.Controller.Play("build_tower")
 				if free || e.currentCurrency >= tower.Price() {
 					if free {
 						e.RemoveItem(e.selectedItem)
 						e.ClearSelectedItem()
 					} else {
-						e.currentCurrency -= tower.Price()
+						e.spendCurrency(tower.Price())
 					}
 					e.grid.towers[e.hoveredTile] = tower
 					e.grid.selectedTower = e.hoveredTile
 				} else {
-					e.grid.ShowMessage(fmt.Sprintf("Not enough currency to place tower. Need %d", tower.Price()))
+					notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.not_enough_to_place", tower.Price()))
 				}
 			}
 		}
-	} else if (e.blueprintSelected != towers.TowerTypeNone || e.freeTurretSelected != towers.TowerTypeNone) && e.hoveredTileIsOnPath && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && isInBounds(e.hoveredTile) {
-		audio.Controller.Play("error", 0.00)
-		e.grid.ShowMessage("Can't place tower on the path.")
+	} else if (e.blueprintSelected != towers.TowerTypeNone || e.freeTurretSelected != towers.TowerTypeNone) && e.hoveredTileIsOnPath && clicked && isInBounds(e.hoveredTile) {
+		notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.not_on_path"))
 	} else if isInBounds(e.hoveredTile) && e.hoveredTileHasTower {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if clicked {
 			e.blueprintSelected = towers.TowerTypeNone
 			if e.freeTurretSelected != towers.TowerTypeNone {
 				e.freeTurretSelected = towers.TowerTypeNone
@@ -445,45 +360,18 @@ func (e *EntityInventory) Update(EntitySpawner) error {
 	}
 
 	// Cancel tower selection and blueprint placement
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+	if input.JustPressed(input.ActionCancel) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 		e.blueprintSelected = towers.TowerTypeNone
 		e.grid.selectedTower = lib.NewVec2I(-1, -1)
 	}
 
 	// Unselect Tower
 	if e.blueprintSelected == towers.TowerTypeNone && isInBounds(e.hoveredTile) && !e.hoveredTileHasTower {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if clicked {
 			e.grid.selectedTower = lib.NewVec2I(-1, -1)
 		}
 	}
 
-	// Upgrade Tower Damage Button and Hotkey
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && isInButton(mouseX, mouseY, e.getButtonPosition(e.damageButton)) {
-		audio.Controller.Play("click", 0.00)
-		e.UpgradeSelectedTowerDamage()
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyD) {
-		audio.Controller.Play("click", 0.00)
-		e.UpgradeSelectedTowerDamage()
-	}
-
-	// Upgrade Tower Speed Button and Hotkey
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && isInButton(mouseX, mouseY, e.getButtonPosition(e.firerateButton)) {
-		audio.Controller.Play("click", 0.00)
-		e.UpgradeSelectedTowerSpeed()
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyS) {
-		audio.Controller.Play("click", 0.00)
-		e.UpgradeSelectedTowerSpeed()
-	}
-
-	// Sell Tower Button and Hotkey
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && isInButton(mouseX, mouseY, e.getButtonPosition(e.removeButton)) {
-		audio.Controller.Play("click", 0.00)
-		e.SellSelectedTower()
-	} else if inpututil.IsKeyJustPressed(ebiten.KeyX) {
-		audio.Controller.Play("click", 0.00)
-		e.SellSelectedTower()
-	}
-
 	return nil
 }
 
@@ -491,288 +379,86 @@ func (e *EntityInventory) Deinit(EntitySpawner) {
 
 }
 
-func (e *EntityInventory) Draw(screen *ebiten.Image) {
-	buttonOutline := color.RGBA{100, 255, 100, 255}
-
+// DrawWorld implements WorldDrawer.
+func (e *EntityInventory) DrawWorld(screen *ebiten.Image) {
 	// Tower Placement
-	outlineColor := color.RGBA{100, 255, 100, 255}
-	if e.hoveredTileHasTower || e.hoveredTileIsOnPath {
-		outlineColor = color.RGBA{255, 100, 100, 255}
-	}
-	if (e.blueprintSelected != towers.TowerTypeNone || e.freeTurretSelected != towers.TowerTypeNone) && isInBounds(e.hoveredTile) {
+	invalid := e.hoveredTileHasTower || e.hoveredTileIsOnPath
+	if e.cursorActive && e.cursorInWorld() {
 		vector.StrokeRect(screen,
-			float32(e.hoveredTile.X*e.tilePixels),
-			float32(e.hoveredTile.Y*e.tilePixels),
+			float32(e.cursor.X*e.tilePixels),
+			float32(e.cursor.Y*e.tilePixels),
 			float32(e.tilePixels),
 			float32(e.tilePixels),
 			3.0,
-			outlineColor,
+			ui.ColorFocus,
 			false,
 		)
 	}
-
-	// Inventory Bar
-	geomBord := ebiten.GeoM{}
-	geomBord.Translate(0, float64(12*e.tilePixels))
-	screen.DrawImage(e.inventoryBarImage, &ebiten.DrawImageOptions{GeoM: geomBord})
-
-	// Hat
-	geomHat := ebiten.GeoM{}
-	geomHat.Scale(4, 4)
-	geomHat.Translate(float64(7*e.tilePixels+e.tilePixels/2), float64(12*e.tilePixels+e.tilePixels/4))
-	screen.DrawImage(e.hatImage, &ebiten.DrawImageOptions{GeoM: geomHat})
-
-	// Hat Percentage
-	manaPercentage := int(float32(e.currentMana) / float32(e.maximumMana) * 100.0)
-	hatTextOptions := &text.DrawOptions{}
-	hatTextOptions.GeoM.Translate(float64(7*e.tilePixels+5*e.tilePixels/8), float64(13*e.tilePixels+e.tilePixels/8))
-	if manaPercentage < 15 {
-		hatTextOptions.ColorScale.Reset()
-		hatTextOptions.ColorScale.Scale(1.0, 1.0, 1.0, 1.0)
-	} else if manaPercentage < 50 {
-		hatTextOptions.ColorScale.Reset()
-		hatTextOptions.ColorScale.Scale(0.5, 1.0, 0.5, 1.0)
-	} else if manaPercentage < 75 {
-		hatTextOptions.ColorScale.Reset()
-		hatTextOptions.ColorScale.Scale(1.0, 1.0, 0.0, 1.0)
-	} else {
-		hatTextOptions.ColorScale.Reset()
-		hatTextOptions.ColorScale.Scale(1.0, 0.0, 0.0, 1.0)
-	}
-	text.Draw(screen, fmt.Sprintf("%03d%%", manaPercentage), e.textFace, hatTextOptions)
-
-	// Currency Display
-	geom := ebiten.GeoM{}
-	geom.Translate(float64(20), float64(12*e.tilePixels+118+24+28+28))
-	text.Draw(screen, fmt.Sprintf("Currency: %d", e.currentCurrency), e.textFace, &text.DrawOptions{
-		DrawImageOptions: ebiten.DrawImageOptions{GeoM: geom},
-	})
-
-	// Wave Display
-	geomWave := ebiten.GeoM{}
-	geomWave.Translate(float64(20), float64(12*e.tilePixels+118+16))
-	waveDisplayOptions := &text.DrawOptions{DrawImageOptions: ebiten.DrawImageOptions{
-		GeoM: geomWave,
-	}}
-	if !e.peace {
-		waveDisplayOptions.ColorScale.Scale(1.0, 0.0, 0.0, 1.0)
-	}
-	text.Draw(screen, fmt.Sprintf("Wave: %d", e.waveCounter), e.textFace, waveDisplayOptions)
-
-	// Health Display
-	geomHealth := ebiten.GeoM{}
-	geomHealth.Translate(20, float64(12*e.tilePixels+118+10+24+14))
-	text.Draw(screen, fmt.Sprintf("Health: %d", e.grid.Health), e.textFace, &text.DrawOptions{
-		DrawImageOptions: ebiten.DrawImageOptions{GeoM: geomHealth},
-	})
-
-	// Damage Boost Display
-	geomDamage := ebiten.GeoM{}
-	geomDamage.Translate(float64(12*e.tilePixels+60), float64(12*e.tilePixels+118+26))
-	if e.damageBoostActive != 0 {
-		text.Draw(screen, fmt.Sprintf("DMG-%d: %d", e.damageBoostActive, int(e.damageBoostDuration)), e.textFace, &text.DrawOptions{
-			DrawImageOptions: ebiten.DrawImageOptions{GeoM: geomDamage},
-		})
-	}
-
-	// Speed Boost Display
-	geomSpeed := ebiten.GeoM{}
-	geomSpeed.Translate(float64(12*e.tilePixels+60), float64(12*e.tilePixels+118+24+20+28))
-	if e.speedBoostActive != 0 {
-		text.Draw(screen, fmt.Sprintf("SPD-%d: %d", e.speedBoostActive, int(e.speedBoostDuration)), e.textFace, &text.DrawOptions{
-			DrawImageOptions: ebiten.DrawImageOptions{GeoM: geomSpeed},
-		})
-	}
-
-	// Item Slots
-	for index, _ := range e.inventory {
-		itemPosition := e.getButtonPosition(lib.NewVec2I(index+5, 0))
-		geomItem := ebiten.GeoM{}
-		geomItem.Scale(4, 4)
-		geomItem.Translate(float64(itemPosition.X), float64(itemPosition.Y))
-		screen.DrawImage(e.inventorySlotImage, &ebiten.DrawImageOptions{GeoM: geomItem})
-	}
-
-	// Item Icons
-	for index, itemType := range e.inventory {
-		if itemType != NoItem {
-			itemPos := e.getButtonTowerIconPosition(lib.NewVec2I(index+5, 0))
-			geomIcon := ebiten.GeoM{}
-			geomIcon.Scale(4, 4)
-			geomIcon.Translate(float64(itemPos.X), float64(itemPos.Y))
-			screen.DrawImage(e.GetItemIcon(itemType), &ebiten.DrawImageOptions{GeoM: geomIcon})
-		}
-	}
-
-	// Item Slot Highlighting
-	if e.selectedItem == 0 {
-		e.highlightButton(e.getButtonPosition(lib.NewVec2I(5, 0)), buttonOutline, screen)
-	} else if e.selectedItem == 1 {
-		e.highlightButton(e.getButtonPosition(lib.NewVec2I(6, 0)), buttonOutline, screen)
-	} else if e.selectedItem == 2 {
-		e.highlightButton(e.getButtonPosition(lib.NewVec2I(7, 0)), buttonOutline, screen)
-	} else if e.selectedItem == 3 {
-		e.highlightButton(e.getButtonPosition(lib.NewVec2I(8, 0)), buttonOutline, screen)
+	if (e.blueprintSelected != towers.TowerTypeNone || e.freeTurretSelected != towers.TowerTypeNone) && isInBounds(e.hoveredTile) {
+		e.drawPlacement(screen, e.hoveredTile, invalid)
 	}
+}
 
-	// Tower Buttons
-	for i := 2; i < 7; i++ {
-		towerButtonPosition := e.getButtonPosition(lib.NewVec2I(i, 1))
-		geomT1bg := ebiten.GeoM{}
-		geomT1bg.Scale(4, 4)
-		geomT1bg.Translate(float64(towerButtonPosition.X), float64(towerButtonPosition.Y))
-		screen.DrawImage(e.inventorySlotImage, &ebiten.DrawImageOptions{GeoM: geomT1bg})
+// drawPlacement outlines the tile a tower would be placed on. In high contrast
+// mode the outline is thicker and invalid tiles are crossed out, so they do
+// not depend on color alone.
+func (e *EntityInventory) drawPlacement(screen *ebiten.Image, tile lib.Vec2I, invalid bool) {
+	x, y, size := float32(tile.X*e.tilePixels), float32(tile.Y*e.tilePixels), float32(e.tilePixels)
+	outlineColor := palette.Good()
+	if invalid {
+		outlineColor = palette.Bad()
 	}
-
-	// Tower Button Icons
-	// Basic Tower
-	basicTowerImgPos := e.getButtonTowerIconPosition(e.basicTowerButton)
-	geomT1im := ebiten.GeoM{}
-	geomT1im.Scale(4, 4)
-	geomT1im.Translate(float64(basicTowerImgPos.X), float64(basicTowerImgPos.Y))
-	screen.DrawImage(e.basicTowerImage, &ebiten.DrawImageOptions{GeoM: geomT1im})
-	// Tack Tower
-	tackTowerImgPos := e.getButtonTowerIconPosition(e.tackTowerButton)
-	geomT2im := ebiten.GeoM{}
-	geomT2im.Scale(4, 4)
-	geomT2im.Translate(float64(tackTowerImgPos.X), float64(tackTowerImgPos.Y))
-	screen.DrawImage(e.tackTowerImage, &ebiten.DrawImageOptions{GeoM: geomT2im})
-	// Ice Tower
-	iceTowerImgPos := e.getButtonTowerIconPosition(e.iceTowerButton)
-	geomT3im := ebiten.GeoM{}
-	geomT3im.Scale(4, 4)
-	geomT3im.Translate(float64(iceTowerImgPos.X), float64(iceTowerImgPos.Y))
-	screen.DrawImage(e.iceTowerImage, &ebiten.DrawImageOptions{GeoM: geomT3im})
-	// AOE Tower
-	aoeTowerImgPos := e.getButtonTowerIconPosition(e.aoeTowerButton)
-	geomT4im := ebiten.GeoM{}
-	geomT4im.Scale(4, 4)
-	geomT4im.Translate(float64(aoeTowerImgPos.X), float64(aoeTowerImgPos.Y))
-	screen.DrawImage(e.aoeTowerImage, &ebiten.DrawImageOptions{GeoM: geomT4im})
-	// Super Tower
-	cashTowerImgPos := e.getButtonTowerIconPosition(e.superTowerButton)
-	geomT5im := ebiten.GeoM{}
-	geomT5im.Scale(4, 4)
-	geomT5im.Translate(float64(cashTowerImgPos.X), float64(cashTowerImgPos.Y))
-	screen.DrawImage(e.superTowerImage, &ebiten.DrawImageOptions{GeoM: geomT5im})
-
-	// Select Tower
-	if e.blueprintSelected == towers.TowerTypeBasic {
-		e.highlightButton(e.getButtonPosition(e.basicTowerButton), buttonOutline, screen)
-	} else if e.blueprintSelected == towers.TowerTypeTacks {
-		e.highlightButton(e.getButtonPosition(e.tackTowerButton), buttonOutline, screen)
-	} else if e.blueprintSelected == towers.TowerTypeIce {
-		e.highlightButton(e.getButtonPosition(e.iceTowerButton), buttonOutline, screen)
-	} else if e.blueprintSelected == towers.TowerTypeAoe {
-		e.highlightButton(e.getButtonPosition(e.aoeTowerButton), buttonOutline, screen)
-	} else if e.blueprintSelected == towers.TowerTypeSuper {
-		e.highlightButton(e.getButtonPosition(e.superTowerButton), buttonOutline, screen)
+	if !settings.Current.HighContrast {
+		vector.StrokeRect(screen, x, y, size, size, 3.0, outlineColor, false)
+		return
 	}
-
-	// Menu Buttons
-	for i := 0; i < 4; i++ {
-		buttonPos := e.getButtonPosition(lib.NewVec2I(i, 0))
-		buttonImgOptions := &ebiten.DrawImageOptions{}
-		buttonImgOptions.GeoM.Scale(4, 4)
-		buttonImgOptions.GeoM.Translate(float64(buttonPos.X), float64(buttonPos.Y))
-		screen.DrawImage(e.inventorySlotImage, buttonImgOptions)
+	vector.StrokeRect(screen, x, y, size, size, 9.0, palette.Outline, false)
+	vector.StrokeRect(screen, x, y, size, size, 5.0, outlineColor, false)
+	if invalid {
+		const inset = 12
+		vector.StrokeLine(screen, x+inset, y+inset, x+size-inset, y+size-inset, 5, outlineColor, false)
+		vector.StrokeLine(screen, x+size-inset, y+inset, x+inset, y+size-inset, 5, outlineColor, false)
 	}
+}
 
-	// Menu Button Icons
-	// Play Button
-	playButtonImagePos := e.getButtonTowerIconPosition(e.playButton)
-	geomUI1 := ebiten.GeoM{}
-	geomUI1.Scale(4, 4)
-	geomUI1.Translate(float64(playButtonImagePos.X), float64(playButtonImagePos.Y))
-	screen.DrawImage(e.playButtonImage, &ebiten.DrawImageOptions{GeoM: geomUI1})
-	// Remove Button
-	removeButtonImagePos := e.getButtonTowerIconPosition(e.removeButton)
-	geomUI2 := ebiten.GeoM{}
-	geomUI2.Scale(4, 4)
-	geomUI2.Translate(float64(removeButtonImagePos.X), float64(removeButtonImagePos.Y))
-	screen.DrawImage(e.dollarImage, &ebiten.DrawImageOptions{GeoM: geomUI2})
-	// Damage Upgrade Button
-	damageButtonImagePos := e.getButtonTowerIconPosition(e.damageButton)
-	geomUI3 := ebiten.GeoM{}
-	geomUI3.Scale(4, 4)
-	geomUI3.Translate(float64(damageButtonImagePos.X), float64(damageButtonImagePos.Y))
-	screen.DrawImage(e.damageButtonImage, &ebiten.DrawImageOptions{GeoM: geomUI3})
-	// Fire Rate Upgrade Button
-	firerateButtonImagePos := e.getButtonTowerIconPosition(e.firerateButton)
-	geomUI4 := ebiten.GeoM{}
-	geomUI4.Scale(4, 4)
-	geomUI4.Translate(float64(firerateButtonImagePos.X), float64(firerateButtonImagePos.Y))
-	screen.DrawImage(e.firerateButtonImage, &ebiten.DrawImageOptions{GeoM: geomUI4})
+func (e *EntityInventory) Draw(screen *ebiten.Image) {
+	e.ui.Draw(screen)
 
 	// Upgrade Indicators
 	if e.isTowerSelected() {
 		tow := e.grid.towers[e.grid.selectedTower]
-		// Damage
-		dmgButtonPos := e.getButtonPosition(e.damageButton)
-		for i := 0; i < int(tow.GetDamageUpgrades()); i++ {
-			geomDI := ebiten.GeoM{}
-			geomDI.Scale(4, 4)
-			geomDI.Translate(float64(dmgButtonPos.X)+(16.0+4.0)*float64(i), float64(dmgButtonPos.Y))
-			screen.DrawImage(e.upgradeIndicatorImage, &ebiten.DrawImageOptions{GeoM: geomDI})
-		}
-
-		// Speed
-		spdButtonPos := e.getButtonPosition(e.firerateButton)
-		for i := 0; i < int(tow.GetSpeedUpgrades()); i++ {
-			geomSI := ebiten.GeoM{}
-			geomSI.Scale(4, 4)
-			geomSI.Translate(float64(spdButtonPos.X)+(16.0+4.0)*float64(i), float64(spdButtonPos.Y))
-			screen.DrawImage(e.upgradeIndicatorImage, &ebiten.DrawImageOptions{GeoM: geomSI})
+		for branch, button := range e.upgradeButtons {
+			pos := button.Rect.Min
+			for i := 0; i < tow.BranchLevel(branch); i++ {
+				geom := ebiten.GeoM{}
+				geom.Scale(4, 4)
+				geom.Translate(float64(pos.X)+(16.0+4.0)*float64(i), float64(pos.Y))
+				screen.DrawImage(SpriteUpgradeIndicator, &ebiten.DrawImageOptions{GeoM: geom})
+			}
 		}
-
 	}
 }
 
-func isInButton(mouseX int, mouseY int, button lib.Vec2I) bool {
-	return mouseX >= button.X && mouseX < button.X+96 && mouseY >= button.Y && mouseY < button.Y+96
-}
-
-func (e *EntityInventory) highlightButton(button lib.Vec2I, col color.RGBA, screen *ebiten.Image) {
-	vector.StrokeRect(screen,
-		float32(button.X),
-		float32(button.Y),
-		float32(96),
-		float32(96),
-		3.0,
-		col,
-		false,
-	)
+// CycleFastForward switches between 1x, 2x and 3x speed.
+func (e *EntityInventory) CycleFastForward() {
+	e.fastForward = e.fastForward%maxFastForward + 1
 }
 
-func (e *EntityInventory) isInHatButton(mouseX int, mouseY int) bool {
-	hatButtonX := int(7*e.tilePixels + e.tilePixels/2)
-	hatButtonY := int(12*e.tilePixels + e.tilePixels/4)
-	return mouseX >= hatButtonX && mouseX < hatButtonX+e.tilePixels && mouseY >= hatButtonY && mouseY < hatButtonY+5*e.tilePixels/4
+// FastForward returns the factor the game speed is multiplied with.
+func (e *EntityInventory) FastForward() float64 {
+	return float64(e.fastForward)
 }
 
 func (e *EntityInventory) ToggleTowerIndicator() {
 	if e.turretRangeIndicator {
-		e.grid.ShowMessage("Range indicator disabled.")
+		notify.Default.Push(notify.CategoryInfo, notify.PriorityLow, i18n.T("msg.range_disabled"))
 	} else {
-		e.grid.ShowMessage("Range indicator enabled.")
+		notify.Default.Push(notify.CategoryInfo, notify.PriorityLow, i18n.T("msg.range_enabled"))
 	}
 	e.turretRangeIndicator = !e.turretRangeIndicator
 	e.grid.towerRangeIndicator = e.turretRangeIndicator
 }
 
-func (e *EntityInventory) getButtonPosition(position lib.Vec2I) lib.Vec2I {
-	buttonPos := lib.NewVec2I(0, 0)
-	buttonPos.X = int(24 + position.X*(e.buttonPixels+14))
-	buttonPos.Y = int(12*e.tilePixels + 18 + position.Y*(e.buttonPixels+18))
-	return buttonPos
-}
-
-func (e *EntityInventory) getButtonTowerIconPosition(position lib.Vec2I) lib.Vec2I {
-	buttonPos := e.getButtonPosition(position)
-	iconPos := lib.NewVec2I(buttonPos.X+16, buttonPos.Y+16)
-	return iconPos
-}
-
 func (e *EntityInventory) selectTowerType(towerType towers.TowerType) {
 	if e.blueprintSelected == towerType {
 		e.blueprintSelected = towers.TowerTypeNone
@@ -785,83 +471,89 @@ func (e *EntityInventory) selectTowerType(towerType towers.TowerType) {
 	}
 }
 
+// isFinalWaveReached reports whether all waves of a map with a victory
+// condition have been started.
+func (e *EntityInventory) isFinalWaveReached() bool {
+	return e.victoryWave > 0 && e.waveCounter >= e.victoryWave
+}
+
 func (e *EntityInventory) StartWave() {
+	if e.isFinalWaveReached() {
+		notify.Default.Push(notify.CategoryWave, notify.PriorityHigh, i18n.T("msg.final_wave"))
+		return
+	}
+
+	// Starting before the prep phase ran out is rewarded with a bonus
+	// proportional to the remaining time.
+	var earlyBonus int64 = 0
+	if e.peace && e.prepTimer > 0 {
+		earlyBonus = int64(e.prepTimer * earlyStartBonusPerSecond)
+		e.earnCurrency(earlyBonus)
+	}
+	e.prepTimer = 0
 	e.currentWave = append(e.currentWave, e.waveController.GenerateNextWave()...)
+	audio.Controller.Duck(0.4, 1.0)
 	e.peace = false
 	e.waveCounter++
-	e.grid.ShowMessage(fmt.Sprintf("Wave %d started! (Strength: %d)", e.waveCounter, e.waveController.GetResources()))
+	message := i18n.T("msg.wave_started", e.waveCounter, e.waveController.GetResources())
+	if earlyBonus > 0 {
+		message = i18n.T("msg.wave_started_early", e.waveCounter, e.waveController.GetResources(), earlyBonus)
+	}
+	for _, enemyType := range e.waveController.NewEnemyTypes() {
+		message += " " + i18n.T("msg.new_enemy", enemyType)
+	}
+	notify.Default.Push(notify.CategoryWave, notify.PriorityHigh, message)
 	e.waveController.IncreaseResources()
 }
 
 func (e *EntityInventory) SellSelectedTower() {
 	if e.isTowerSelected() {
-		sellPrice := int64(float64(e.grid.towers[e.grid.selectedTower].Price())*0.5) + int64(e.grid.towers[e.grid.selectedTower].GetTotalUpgrades()*100)
+		sellPrice := sellValue(e.grid.towers[e.grid.selectedTower])
 		delete(e.grid.towers, e.grid.selectedTower)
 		e.grid.selectedTower = lib.NewVec2I(-1, -1)
 		e.currentCurrency += sellPrice
-		e.grid.ShowMessage(fmt.Sprintf("Sold selected tower for %d!", sellPrice))
+		notify.Default.Push(notify.CategoryEconomy, notify.PriorityNormal, i18n.T("msg.sold", sellPrice))
 	}
 }
 
-func (e *EntityInventory) UpgradeSelectedTowerSpeed() {
+// UpgradeSelectedTower buys the next upgrade of the given branch for the
+// selected tower. A locked branch can only be upgraded with a Max Upgrade item.
+func (e *EntityInventory) UpgradeSelectedTower(branch int) {
 	if !e.isTowerSelected() {
 		return
 	}
 	tower := e.grid.towers[e.grid.selectedTower]
-	if tower.GetTotalUpgrades() >= 7 && !e.maxUpgradeSelected {
-		e.grid.ShowMessage("This tower has reached the maximum amount of total upgrades!")
-	} else if tower.GetSpeedUpgrades() >= 5 {
-		e.grid.ShowMessage("This tower has reached the maximum amount of speed upgrades!")
-	} else {
-		upgradePrice := int64(float64(tower.GetTotalUpgrades()+1) * 100.0)
-		if e.currentCurrency >= upgradePrice || e.freeUpgradeSelected {
-			if e.maxUpgradeSelected && tower.GetTotalUpgrades() >= 7 {
-				e.RemoveItem(e.selectedItem)
-				e.ClearSelectedItem()
-			}
-			if e.freeUpgradeSelected {
-				e.RemoveItem(e.selectedItem)
-				e.ClearSelectedItem()
-			} else {
-				e.currentCurrency -= upgradePrice
-			}
-			tower.SpeedUpgrade()
-			e.grid.ShowMessage("Tower speed upgraded!")
-		} else {
-			e.grid.ShowMessage(fmt.Sprintf("Not enough currency! (Required: %d)", upgradePrice))
-		}
-	}
-}
-
-func (e *EntityInventory) UpgradeSelectedTowerDamage() {
-	if !e.isTowerSelected() {
+	b, ok := towers.UpgradeTree(tower.Type()).Branch(branch)
+	if !ok {
 		return
 	}
-	tower := e.grid.towers[e.grid.selectedTower]
-	if tower.GetTotalUpgrades() >= 7 && !e.maxUpgradeSelected {
-		e.grid.ShowMessage("This tower has reached the maximum amount of total upgrades!")
-	} else if tower.GetDamageUpgrades() >= 5 {
-		e.grid.ShowMessage("This tower has reached the maximum amount of damage upgrades!")
-	} else {
-		upgradePrice := int64(float64(tower.GetTotalUpgrades()+1) * 100.0)
-		if e.currentCurrency >= upgradePrice || e.freeUpgradeSelected {
-			if e.maxUpgradeSelected && tower.GetTotalUpgrades() >= 7 {
-				e.RemoveItem(e.selectedItem)
-				e.ClearSelectedItem()
-			}
-			if e.freeUpgradeSelected {
-				e.RemoveItem(e.selectedItem)
-				e.ClearSelectedItem()
-			} else {
-				e.currentCurrency -= upgradePrice
-			}
-			tower.DamageUpgrade()
-			e.grid.ShowMessage("Tower damage upgraded!")
+	upgrade, ok := tower.NextUpgrade(branch)
+	locked := tower.BranchLocked(branch)
+	if !ok {
+		notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.branch_maxed", b.Name()))
+	} else if locked && !e.maxUpgradeSelected {
+		notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.branch_locked", b.Name()))
+	} else if e.currentCurrency >= upgrade.Cost || e.freeUpgradeSelected {
+		if e.maxUpgradeSelected && locked {
+			e.RemoveItem(e.selectedItem)
+			e.ClearSelectedItem()
+		}
+		if e.freeUpgradeSelected {
+			e.RemoveItem(e.selectedItem)
+			e.ClearSelectedItem()
 		} else {
-			e.grid.ShowMessage(fmt.Sprintf("Not enough currency! (Required: %d)", upgradePrice))
+			e.spendCurrency(upgrade.Cost)
 		}
+		tower.Upgrade(branch)
+		notify.Default.Push(notify.CategoryEconomy, notify.PriorityNormal, i18n.T("msg.upgraded", upgrade.Name()))
+	} else {
+		notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.not_enough_currency", upgrade.Cost))
 	}
+}
 
+// sellValue returns the currency refunded when selling the tower.
+func sellValue(tower towers.Tower) int64 {
+	return (tower.Price() + tower.UpgradeValue()) / 2
 }
 
 func (e *EntityInventory) isTowerSelected() bool {
@@ -889,7 +581,7 @@ func (e *EntityInventory) ActivateItem(itemNumber int) {
 	}
 	switch e.inventory[itemNumber] {
 	case NoItem:
-		e.grid.ShowMessage("This slot is empty!")
+		notify.Default.Push(notify.CategoryError, notify.PriorityHigh, i18n.T("msg.slot_empty"))
 	case BasicTower:
 		e.SelectFreeTurret(towers.TowerTypeBasic, itemNumber)
 	case TackTower:
@@ -918,7 +610,7 @@ func (e *EntityInventory) ActivateItem(itemNumber int) {
 	case ClearEnemies:
 		e.grid.NukeEnemies()
 		e.RemoveItem(itemNumber)
-		e.grid.ShowMessage("Nuked all enemies!")
+		notify.Default.Push(notify.CategoryInfo, notify.PriorityHigh, i18n.T("msg.nuked"))
 	case DamageBuffSmall:
 		e.DamageBuff(1, itemNumber)
 	case DamageBuffMedium:
@@ -943,7 +635,7 @@ func (e *EntityInventory) DamageBuff(level int, itemNumber int) {
 	}
 	e.grid.BuffAllTowersDamage(float32(damageModifier), float32(damageDuration))
 	e.RemoveItem(itemNumber)
-	e.grid.ShowMessage(fmt.Sprintf("Activated Level %d damage buff for %d seconds!", level, damageDuration))
+	notify.Default.Push(notify.CategoryInfo, notify.PriorityNormal, i18n.N("msg.damage_buff", damageDuration, level))
 	e.damageBoostActive = level
 	e.damageBoostDuration = float32(damageDuration)
 }
@@ -961,7 +653,7 @@ func (e *EntityInventory) SpeedBuff(level int, itemNumber int) {
 	}
 	e.grid.BuffAllTowersSpeed(float32(speedModifier), float32(speedDuration))
 	e.RemoveItem(itemNumber)
-	e.grid.ShowMessage(fmt.Sprintf("Activated Level %d speed buff for %d seconds!", level, speedDuration))
+	notify.Default.Push(notify.CategoryInfo, notify.PriorityNormal, i18n.N("msg.speed_buff", speedDuration, level))
 	e.speedBoostActive = level
 	e.speedBoostDuration = float32(speedDuration)
 }
@@ -976,9 +668,70 @@ func (e *EntityInventory) CurrencyGift(level int, itemNumber int) {
 	case 3:
 		newCurrency = 2500
 	}
-	e.currentCurrency += newCurrency
+	e.earnCurrency(newCurrency)
 	e.RemoveItem(itemNumber)
-	e.grid.ShowMessage(fmt.Sprintf("Received %d currency!", newCurrency))
+	notify.Default.Push(notify.CategoryEconomy, notify.PriorityNormal, i18n.T("msg.received_currency", newCurrency))
+}
+
+// earnCurrency adds currency that counts towards the score, unlike refunds.
+func (e *EntityInventory) earnCurrency(amount int64) {
+	e.currentCurrency += amount
+	e.currencyEarned += amount
+}
+
+// spendCurrency removes currency and tracks it for the run statistics.
+func (e *EntityInventory) spendCurrency(amount int64) {
+	e.currentCurrency -= amount
+	e.currencySpent += amount
+}
+
+// SetVictoryWave sets the number of waves that have to be survived to win the
+// map. 0 disables the victory condition.
+func (e *EntityInventory) SetVictoryWave(wave int64) {
+	e.victoryWave = wave
+}
+
+// IsGameOver reports whether the player has lost the run.
+func (e *EntityInventory) IsGameOver() bool {
+	return e.gameOver
+}
+
+// IsVictory reports whether the player has won the run.
+func (e *EntityInventory) IsVictory() bool {
+	return e.victory
+}
+
+// GetRunStats returns the statistics of the current run.
+func (e *EntityInventory) GetRunStats() RunStats {
+	killsByTower := make(map[towers.TowerType]int64)
+	for towerType, kills := range e.grid.killsByTower {
+		killsByTower[towerType] = kills
+	}
+	return RunStats{
+		Score:         e.GetScore(),
+		KillsByTower:  killsByTower,
+		EnemiesLeaked: e.grid.enemiesLeaked,
+		CurrencySpent: e.currencySpent,
+		Endless:       e.waveController.IsEndless(),
+	}
+}
+
+// SetEndlessMode toggles endless mode for the run.
+func (e *EntityInventory) SetEndlessMode(endless bool) {
+	e.waveController.SetEndless(endless)
+}
+
+// GetScore returns the score of the current run.
+func (e *EntityInventory) GetScore() Score {
+	wavesSurvived := e.waveCounter
+	if !e.peace && wavesSurvived > 0 {
+		wavesSurvived--
+	}
+	return Score{
+		WavesSurvived:  wavesSurvived,
+		EnemiesKilled:  e.grid.enemiesKilled,
+		CurrencyEarned: e.currencyEarned,
+	}
 }
 
 func (e *EntityInventory) RemoveItem(itemSlotNumber int) {
@@ -1007,28 +760,28 @@ func (e *EntityInventory) GetItemIcon(itemType Item) *ebiten.Image {
 	case SuperTower:
 		return e.superTowerImage
 	case FreeUpgrade:
-		return e.freeUpgradeImage
+		return SpriteFreeUpgrade
 	case MaxUpgrade:
-		return e.maxUpgradeImage
+		return SpriteMaxUpgrade
 	case CurrencyGiftSmall:
-		return e.dollarImage
+		return SpriteDollar
 	case CurrencyGiftMedium:
-		return e.dollarOrangeImage
+		return SpriteDollarOrange
 	case CurrencyGiftLarge:
-		return e.dollarRedImage
+		return SpriteDollarRed
 	case BombTrap:
 	case ClearEnemies:
-		return e.bombImage
+		return SpriteBomb
 	case DamageBuffSmall:
-		return e.damageSmallImage
+		return SpriteDamageSmall
 	case DamageBuffMedium:
-		return e.damageMediumImage
+		return SpriteDamageMedium
 	case SpeedBuffSmall:
-		return e.speedSmallImage
+		return SpriteSpeedSmall
 	case SpeedBuffMedium:
-		return e.speedMediumImage
+		return SpriteSpeedMedium
 	}
-	return e.removeButtonImage
+	return SpriteRemoveButton
 }
 
 func (e *EntityInventory) ActivateHat() {
@@ -1046,9 +799,9 @@ func (e *EntityInventory) ActivateHat() {
 		newCurrency += int64(float64(e.currentMana) * 5.0 * 2.0)
 		e.GenerateRandomItem(LegendaryItem)
 	}
-	e.currentCurrency += newCurrency
+	e.earnCurrency(newCurrency)
 	e.currentMana = 0
-	e.grid.ShowMessage(fmt.Sprintf("Received %d currency!", newCurrency))
+	notify.Default.Push(notify.CategoryEconomy, notify.PriorityNormal, i18n.T("msg.received_currency", newCurrency))
 }
 
 func (e *EntityInventory) AddItem(itemType Item) {
@@ -1106,6 +859,8 @@ func (e *EntityInventory) RestartGame() {
 	e.blueprintSelected = 0
 	// Reset Mana/Currency
 	e.currentCurrency = 1000 // TODO: balance
+	e.currencyEarned = 0
+	e.currencySpent = 0
 	e.currentMana = 0
 	// Reset Waves
 	e.waveCounter = 0
@@ -1113,6 +868,9 @@ func (e *EntityInventory) RestartGame() {
 	e.currentWave = []enemy.EnemyType{}
 	// Reset Spawns
 	e.peace = true
+	e.prepTimer = prepPhaseDuration
 	e.enemySpawnTimer = 0.0
-
+	e.fastForward = 1
+	e.gameOver = false
+	e.victory = false
 }
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"jamegam/pkg/camera"
+	"jamegam/pkg/display"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// The cursor lets the game be played with the keyboard or a gamepad. It moves
+// over the tiles of the map and continues into the two button rows of the
+// inventory bar below, where it focuses the button it is on.
+
+// barColumns is the number of button columns in the inventory bar.
+const barColumns = 9
+
+// updateCursor moves the cursor and hands the focus to the bar button below
+// it. Using the mouse hides the cursor again.
+func (e *EntityInventory) updateCursor() {
+	mouseX, mouseY := display.CursorPosition()
+	mouse := lib.NewVec2I(mouseX, mouseY)
+	if mouse != e.lastMouse || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		e.cursorActive = false
+	}
+	e.lastMouse = mouse
+
+	dx, dy := 0, 0
+	if input.Repeated(input.ActionCursorLeft) {
+		dx--
+	}
+	if input.Repeated(input.ActionCursorRight) {
+		dx++
+	}
+	if input.Repeated(input.ActionCursorUp) {
+		dy--
+	}
+	if input.Repeated(input.ActionCursorDown) {
+		dy++
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	if !e.cursorActive {
+		e.cursorActive = true
+		if isInBounds(e.hoveredTile) {
+			e.cursor = e.hoveredTile // continue where the mouse was
+		}
+	} else if e.cursorInWorld() {
+		e.moveCursorInWorld(dx, dy)
+	} else {
+		e.moveCursorInBar(dx, dy)
+	}
+
+	if e.cursorInWorld() {
+		e.ui.Focus(nil)
+		// Pan to the tile when zoomed in
+		tile := float32(e.tilePixels)
+		camera.Main.ShowRect(float32(e.cursor.X)*tile, float32(e.cursor.Y)*tile, tile, tile)
+	} else {
+		e.ui.Focus(e.barWidgets[e.barCell()])
+	}
+}
+
+func (e *EntityInventory) cursorInWorld() bool {
+	return e.cursor.Y < 12
+}
+
+// barCell returns the column and row of the bar button the cursor is on.
+func (e *EntityInventory) barCell() lib.Vec2I {
+	return lib.NewVec2I(e.cursor.X, e.cursor.Y-12)
+}
+
+func (e *EntityInventory) moveCursorInWorld(dx, dy int) {
+	e.cursor.X = min(15, max(0, e.cursor.X+dx))
+	e.cursor.Y = max(0, e.cursor.Y+dy)
+	if e.cursor.Y == 12 {
+		// Enter the bar at the button below the tile
+		e.cursor.X = min(barColumns-1, max(0, (e.cursor.X*e.tilePixels+e.tilePixels/2-24)/(e.buttonPixels+14)))
+	}
+}
+
+func (e *EntityInventory) moveCursorInBar(dx, dy int) {
+	cell := e.barCell()
+	if dx != 0 {
+		// Skip the empty cells of the row
+		for x := cell.X + dx; x >= 0 && x < barColumns; x += dx {
+			if _, ok := e.barWidgets[lib.NewVec2I(x, cell.Y)]; ok {
+				cell.X = x
+				break
+			}
+		}
+	}
+	if dy < 0 && cell.Y == 0 {
+		// Leave the bar to the tile above the button
+		buttonCenter := 24 + cell.X*(e.buttonPixels+14) + e.buttonPixels/2
+		e.cursor = lib.NewVec2I(buttonCenter/e.tilePixels, 11)
+		return
+	}
+	if dy != 0 {
+		row := min(1, max(0, cell.Y+dy))
+		if _, ok := e.barWidgets[lib.NewVec2I(cell.X, row)]; ok {
+			cell.Y = row
+		} else if row == 1 {
+			cell = lib.NewVec2I(min(6, max(2, cell.X)), 1) // the tower buttons
+		}
+	}
+	e.cursor = lib.NewVec2I(cell.X, cell.Y+12)
+}
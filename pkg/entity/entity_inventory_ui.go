@@ -0,0 +1,304 @@
+package entity
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/notify"
+	"jamegam/pkg/palette"
+	"jamegam/pkg/towers"
+	"jamegam/pkg/ui"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// buildUI creates the widgets of the inventory bar.
+func (e *EntityInventory) buildUI() {
+	barY := 12 * e.tilePixels
+	buttons := ui.Grid{
+		Origin: image.Pt(24, barY+18),
+		Cell:   image.Pt(e.buttonPixels, e.buttonPixels),
+		Gap:    image.Pt(14, 18),
+	}
+
+	bar := ui.NewPanel(image.Rect(0, barY, 1024, barY+SpriteInventoryBar.Bounds().Dy()))
+	bar.Image = SpriteInventoryBar
+
+	// Menu Buttons
+	play := ui.NewIconButton(buttons.At(0, 0), SpriteInventorySlot, SpritePlayButton, e.StartWave)
+	play.TooltipFunc = withKeys("tooltip.start_wave", input.ActionStartWave)
+	play.Action = input.ActionStartWave
+	sell := ui.NewIconButton(buttons.At(1, 0), SpriteInventorySlot, SpriteDollar, e.SellSelectedTower)
+	sell.TooltipFunc = withKeys("tooltip.sell", input.ActionSell)
+	sell.Action = input.ActionSell
+	bar.Items = append(bar.Items, play, sell)
+	e.barWidgets = map[lib.Vec2I]ui.Widget{
+		lib.NewVec2I(0, 0): play,
+		lib.NewVec2I(1, 0): sell,
+	}
+
+	// Upgrade Buttons, one per branch of the upgrade tree
+	upgradeActions := [towers.BranchCount]input.Action{input.ActionUpgradeLeft, input.ActionUpgradeRight}
+	for branch := range e.upgradeButtons {
+		action := upgradeActions[branch]
+		button := ui.NewIconButton(buttons.At(branch+2, 0), SpriteInventorySlot, nil, func() { e.UpgradeSelectedTower(branch) })
+		button.IconFunc = func() *ebiten.Image { return e.branchIcon(branch) }
+		button.TooltipFunc = func() string { return e.upgradeTooltip(branch, action) }
+		button.Action = action
+		bar.Items = append(bar.Items, button)
+		e.barWidgets[lib.NewVec2I(branch+2, 0)] = button
+		e.upgradeButtons[branch] = button
+	}
+
+	// Item Slots
+	for i := range e.inventory {
+		slot := i
+		button := ui.NewIconButton(buttons.At(slot+5, 0), SpriteInventorySlot, nil, func() { e.ActivateItem(slot) })
+		button.IconFunc = func() *ebiten.Image {
+			if e.inventory[slot] == NoItem {
+				return nil
+			}
+			return e.GetItemIcon(e.inventory[slot])
+		}
+		button.Selected = func() bool { return e.selectedItem == slot }
+		bar.Items = append(bar.Items, button)
+		e.barWidgets[lib.NewVec2I(slot+5, 0)] = button
+	}
+
+	// Tower Buttons
+	towerButtons := []struct {
+		towerType towers.TowerType
+		icon      *ebiten.Image
+		action    input.Action
+	}{
+		{towers.TowerTypeBasic, e.basicTowerImage, input.ActionTowerBasic},
+		{towers.TowerTypeTacks, e.tackTowerImage, input.ActionTowerTacks},
+		{towers.TowerTypeIce, e.iceTowerImage, input.ActionTowerIce},
+		{towers.TowerTypeAoe, e.aoeTowerImage, input.ActionTowerAoe},
+		{towers.TowerTypeSuper, e.superTowerImage, input.ActionTowerSuper},
+	}
+	for i, tb := range towerButtons {
+		action := tb.action
+		towerType := tb.towerType
+		button := ui.NewIconButton(buttons.At(i+2, 1), SpriteInventorySlot, tb.icon, func() {
+			notify.Default.Push(notify.CategoryEconomy, notify.PriorityLow, i18n.T("msg.cost", towers.Info(towerType).Price))
+			e.selectTowerType(towerType)
+		})
+		button.TooltipFunc = func() string { return towerTooltip(towerType, action) }
+		button.Action = action
+		button.Selected = func() bool { return e.blueprintSelected == towerType }
+		bar.Items = append(bar.Items, button)
+		e.barWidgets[lib.NewVec2I(i+2, 1)] = button
+	}
+
+	fastForward := ui.NewButton(buttons.At(7, 1), "", e.CycleFastForward)
+	fastForward.LabelFunc = func() string { return fmt.Sprintf("%dx", e.fastForward) }
+	fastForward.TooltipFunc = withKeys("tooltip.fast_forward", input.ActionFastForward)
+	fastForward.Action = input.ActionFastForward
+	bar.Items = append(bar.Items, fastForward)
+	e.barWidgets[lib.NewVec2I(7, 1)] = fastForward
+
+	// Hat
+	hatPos := image.Pt(7*e.tilePixels+e.tilePixels/2, barY+e.tilePixels/4)
+	hat := ui.NewIconButton(image.Rectangle{Min: hatPos, Max: hatPos.Add(image.Pt(e.tilePixels, 5*e.tilePixels/4))}, nil, SpriteHat, e.ActivateHat)
+	hat.IconOffset = image.Point{}
+	hat.TooltipFunc = withKeys("tooltip.hat", input.ActionHat)
+	hat.Action = input.ActionHat
+	e.barWidgets[lib.NewVec2I(4, 0)] = hat // between the upgrade buttons and the items
+	// TODO: other hat sounds
+
+	hatPercentage := ui.NewLabel(image.Pt(7*e.tilePixels+5*e.tilePixels/8, 13*e.tilePixels+e.tilePixels/8), "")
+	hatPercentage.Rect.Max.X += e.tilePixels
+	hatPercentage.TextFunc = func() string {
+		return fmt.Sprintf("%03d%%", e.manaPercentage())
+	}
+	hatPercentage.ColorFunc = func() color.Color {
+		manaPercentage := e.manaPercentage()
+		if manaPercentage < 15 {
+			return color.White
+		} else if manaPercentage < 50 {
+			return palette.Good()
+		} else if manaPercentage < 75 {
+			return palette.Warning()
+		}
+		return palette.Bad()
+	}
+	bar.Items = append(bar.Items, hat, hatPercentage)
+
+	// Status Displays, limited to the space left of the tower buttons
+	statusRight := buttons.At(2, 1).Min.X - 8
+	wave := ui.NewLabel(image.Pt(20, barY+118+16), "")
+	wave.Rect.Max = image.Pt(statusRight, barY+118+10+24+14)
+	wave.TextFunc = func() string {
+		if e.isFinalWaveReached() {
+			return i18n.T("hud.wave_final", e.waveCounter, e.victoryWave)
+		} else if e.peace {
+			return i18n.N("hud.wave_next", int(math.Ceil(e.prepTimer)), e.waveCounter)
+		}
+		return i18n.T("hud.wave", e.waveCounter)
+	}
+	wave.ColorFunc = func() color.Color {
+		if !e.peace {
+			return color.RGBA{255, 0, 0, 255}
+		}
+		return color.White
+	}
+	health := ui.NewLabel(image.Pt(20, barY+118+10+24+14), "")
+	health.Rect.Max = image.Pt(statusRight, barY+118+24+28+28)
+	health.TextFunc = func() string {
+		return i18n.T("hud.health", e.grid.Health)
+	}
+	currency := ui.NewLabel(image.Pt(20, barY+118+24+28+28), "")
+	currency.Rect.Max = image.Pt(statusRight, bar.Rect.Max.Y)
+	currency.TextFunc = func() string {
+		return i18n.T("hud.currency", e.currentCurrency)
+	}
+	damageBoost := ui.NewLabel(image.Pt(12*e.tilePixels+60, barY+118+26), "")
+	damageBoost.Rect.Max = image.Pt(1024-8, barY+118+24+20+28)
+	damageBoost.TextFunc = func() string {
+		if e.damageBoostActive == 0 {
+			return ""
+		}
+		return i18n.T("hud.damage_boost", e.damageBoostActive, int(e.damageBoostDuration))
+	}
+	speedBoost := ui.NewLabel(image.Pt(12*e.tilePixels+60, barY+118+24+20+28), "")
+	speedBoost.Rect.Max = image.Pt(1024-8, bar.Rect.Max.Y)
+	speedBoost.TextFunc = func() string {
+		if e.speedBoostActive == 0 {
+			return ""
+		}
+		return i18n.T("hud.speed_boost", e.speedBoostActive, int(e.speedBoostDuration))
+	}
+	bar.Items = append(bar.Items, wave, health, currency, damageBoost, speedBoost)
+
+	e.towerPanel = e.buildTowerPanel()
+	e.ui = ui.NewRoot(bar, e.towerPanel)
+	e.ui.ManualFocus = true // focus follows the cursor
+}
+
+// buildTowerPanel creates the panel showing the stats of the selected tower.
+func (e *EntityInventory) buildTowerPanel() *ui.Panel {
+	panel := ui.NewPanel(image.Rect(1024-328, 8, 1024-8, 8+360))
+	panel.Color = color.RGBA{20, 20, 20, 200}
+	stats := ui.NewLabel(panel.Rect.Min.Add(image.Pt(12, 10)), "")
+	stats.Rect.Max = panel.Rect.Max.Sub(image.Pt(12, 10))
+	stats.TextFunc = func() string {
+		if !e.isTowerSelected() {
+			return "" // sold since the last update
+		}
+		tower := e.grid.towers[e.grid.selectedTower]
+		return i18n.T("panel.tower",
+			tower.Type().String(),
+			towerStats(tower.Damage(), tower.FireRate(), tower.Radius()),
+			upgradeTreeText(tower),
+			sellValue(tower),
+			tower.Kills(),
+			tower.DamageDealt(),
+		)
+	}
+	panel.Items = append(panel.Items, stats)
+	return panel
+}
+
+// upgradeTreeText lists the branches of the upgrade tree of the tower, marking
+// the bought upgrades and the next one.
+func upgradeTreeText(tower towers.Tower) string {
+	lines := []string{}
+	for branch, b := range towers.UpgradeTree(tower.Type()).Branches {
+		if tower.BranchLocked(branch) {
+			lines = append(lines, i18n.T("panel.branch_locked", b.Name()))
+		} else {
+			lines = append(lines, b.Name())
+		}
+		level := tower.BranchLevel(branch)
+		for i, upgrade := range b.Nodes {
+			if i < level {
+				lines = append(lines, i18n.T("panel.upgrade_bought", upgrade.Name()))
+			} else if i == level {
+				lines = append(lines, i18n.T("panel.upgrade_next", upgrade.Name(), upgrade.Cost))
+			} else {
+				lines = append(lines, i18n.T("panel.upgrade_later", upgrade.Name()))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// upgradeTooltip describes the next upgrade of the branch for the selected
+// tower.
+func (e *EntityInventory) upgradeTooltip(branch int, action input.Action) string {
+	keys := input.KeyNames(action)
+	if !e.isTowerSelected() {
+		return i18n.T("tooltip.upgrade_none", keys)
+	}
+	tower := e.grid.towers[e.grid.selectedTower]
+	b, ok := towers.UpgradeTree(tower.Type()).Branch(branch)
+	if !ok {
+		return i18n.T("tooltip.upgrade_none", keys)
+	}
+	upgrade, ok := tower.NextUpgrade(branch)
+	if !ok {
+		return i18n.T("tooltip.upgrade_maxed", b.Name(), keys)
+	}
+	tip := i18n.T("tooltip.upgrade", b.Name(), upgrade.Name(), keys, upgrade.Cost, upgrade.Description())
+	if tower.BranchLocked(branch) {
+		tip += "\n" + i18n.T("tooltip.upgrade_locked")
+	}
+	return tip
+}
+
+// branchIcon returns the icon of the branch of the selected tower, or nil if
+// there is none.
+func (e *EntityInventory) branchIcon(branch int) *ebiten.Image {
+	if !e.isTowerSelected() {
+		return nil
+	}
+	b, ok := towers.UpgradeTree(e.grid.towers[e.grid.selectedTower].Type()).Branch(branch)
+	if !ok {
+		return nil
+	}
+	icon, ok := branchIcons[b.Icon]
+	if !ok {
+		icon = new(*ebiten.Image)
+		if err := assets.LoadImage(icon, b.Icon); err != nil {
+			log.Printf("Could not load upgrade icon: %v", err)
+		}
+		branchIcons[b.Icon] = icon // a missing icon is only logged once
+	}
+	return *icon
+}
+
+// withKeys returns a tooltip with the message of the key, followed by the
+// current keys of the action.
+func withKeys(key string, action input.Action) func() string {
+	return func() string {
+		return fmt.Sprintf("%s [%s]", i18n.T(key), input.KeyNames(action))
+	}
+}
+
+// towerTooltip describes a tower type in the shop.
+func towerTooltip(towerType towers.TowerType, action input.Action) string {
+	info := towers.Info(towerType)
+	return i18n.T("tooltip.tower",
+		towerType.String(), input.KeyNames(action), info.Price, towerStats(info.Damage, info.FireRate, info.Radius), towerType.Description())
+}
+
+// towerStats formats damage, fire rate and range on one line.
+func towerStats(damage int, fireRate float64, radius float32) string {
+	damageText := "-"
+	if damage > 0 {
+		damageText = fmt.Sprintf("%d", damage)
+	}
+	return i18n.T("tooltip.stats", damageText, fireRate, int(radius))
+}
+
+func (e *EntityInventory) manaPercentage() int {
+	return int(float32(e.currentMana) / float32(e.maximumMana) * 100.0)
+}
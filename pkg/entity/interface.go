@@ -10,6 +10,13 @@ type Entity interface {
 	Draw(screen *ebiten.Image)
 }
 
+// WorldDrawer is implemented by entities that draw into the game world,
+// which is shown through the camera. Their Draw only draws the screen space
+// UI on top.
+type WorldDrawer interface {
+	DrawWorld(world *ebiten.Image)
+}
+
 // EntitySpawner is an interface for adding and removing entities to/from the
 // game.
 type EntitySpawner interface {
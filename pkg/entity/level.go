@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+)
+
+// levelFile defines the map and the enemy path, see Level.
+const levelFile = "level.json"
+
+// Level is the map of the game. Tiles are rows of '.' for the path and 'p'
+// for platforms towers can be built on. Path lists the tiles the enemies walk
+// through, from the spawn outside of the map to the exit.
+type Level struct {
+	Tiles []string `json:"tiles"`
+	Path  [][2]int `json:"path"`
+}
+
+var (
+	level        *Level
+	levelVersion int // counts reloads, so grids notice a changed level
+)
+
+func init() {
+	lib.Must(loadLevel())
+	assets.Watch(levelFile, loadLevel)
+}
+
+func loadLevel() error {
+	reader, err := assets.Open(levelFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	parsed, err := parseLevel(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", levelFile, err)
+	}
+	level = parsed
+	levelVersion++
+	return nil
+}
+
+// parseLevel reads and checks a level file.
+func parseLevel(r io.Reader) (*Level, error) {
+	l := &Level{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(l); err != nil {
+		return nil, err
+	}
+	if len(l.Tiles) == 0 {
+		return nil, fmt.Errorf("the level has no tiles")
+	}
+	for y, row := range l.Tiles {
+		if len(row) != len(l.Tiles[0]) {
+			return nil, fmt.Errorf("row %d has %d tiles, want %d", y, len(row), len(l.Tiles[0]))
+		}
+		for _, char := range row {
+			if char != '.' && char != 'p' {
+				return nil, fmt.Errorf("unknown tile %q in row %d", char, y)
+			}
+		}
+	}
+	if len(l.Path) < 3 {
+		return nil, fmt.Errorf("the path has %d nodes, it needs at least 3", len(l.Path))
+	}
+	return l, nil
+}
+
+func (l *Level) tiles() [][]mapTileType {
+	tiles := make([][]mapTileType, len(l.Tiles))
+	for y, line := range l.Tiles {
+		for _, char := range line {
+			if char == 'p' {
+				tiles[y] = append(tiles[y], mapTileTypePlatform)
+			} else {
+				tiles[y] = append(tiles[y], mapTileTypeEmpty)
+			}
+		}
+	}
+	return tiles
+}
+
+func (l *Level) path() []lib.Vec2I {
+	path := make([]lib.Vec2I, len(l.Path))
+	for i, node := range l.Path {
+		path[i] = lib.NewVec2I(node[0], node[1])
+	}
+	return path
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	l, err := parseLevel(strings.NewReader(`{"tiles": ["p.p", "p.p"], "path": [[1,-1], [1,0], [1,1], [1,2]]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tiles := l.tiles(); tiles[1][1] != mapTileTypeEmpty || tiles[1][2] != mapTileTypePlatform {
+		t.Errorf("got tiles %v", tiles)
+	}
+	if path := l.path(); len(path) != 4 || path[0].Y != -1 {
+		t.Errorf("got path %v", path)
+	}
+}
+
+func TestParseLevelRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"tiles": [], "path": [[0,0], [0,1], [0,2]]}`,
+		`{"tiles": ["pp", "p"], "path": [[0,0], [0,1], [0,2]]}`,
+		`{"tiles": ["px"], "path": [[0,0], [0,1], [0,2]]}`,
+		`{"tiles": ["pp"], "path": [[0,0], [0,1]]}`,
+		`{"tiles": ["pp"], "path": [[0,0], [0,1], [0,2]], "spawn": [0,0]}`,
+	} {
+		if _, err := parseLevel(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
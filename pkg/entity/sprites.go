@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	SpriteInventoryBar     *ebiten.Image
+	SpriteInventorySlot    *ebiten.Image
+	SpriteHat              *ebiten.Image
+	SpritePlayButton       *ebiten.Image
+	SpriteRemoveButton     *ebiten.Image
+	SpriteUpgradeIndicator *ebiten.Image
+)
+
+var (
+	SpriteFreeUpgrade  *ebiten.Image
+	SpriteMaxUpgrade   *ebiten.Image
+	SpriteBomb         *ebiten.Image
+	SpriteSpeedSmall   *ebiten.Image
+	SpriteSpeedMedium  *ebiten.Image
+	SpriteDamageSmall  *ebiten.Image
+	SpriteDamageMedium *ebiten.Image
+	SpriteDollar       *ebiten.Image
+	SpriteDollarOrange *ebiten.Image
+	SpriteDollarRed    *ebiten.Image
+)
+
+// branchIcons holds the upgrade branch icons by file, they are loaded on
+// first use, see branchIcon.
+var branchIcons = map[string]**ebiten.Image{}
+
+func init() {
+	// BAR
+
+	lib.Must(assets.LoadImage(&SpriteInventoryBar, "menu_bar_1024x246.png"))
+	lib.Must(assets.LoadImage(&SpriteInventorySlot, "inventory_slot.png"))
+	lib.Must(assets.LoadImage(&SpriteHat, "test_hat.png"))
+	lib.Must(assets.LoadImage(&SpritePlayButton, "test_playbutton.png"))
+	lib.Must(assets.LoadImage(&SpriteRemoveButton, "test_removebutton.png"))
+	lib.Must(assets.LoadImage(&SpriteUpgradeIndicator, "upgradeindicator.png"))
+
+	// ITEMS
+
+	lib.Must(assets.LoadImage(&SpriteFreeUpgrade, "freeUpgrade.png"))
+	lib.Must(assets.LoadImage(&SpriteMaxUpgrade, "maxUpgrade.png"))
+	lib.Must(assets.LoadImage(&SpriteBomb, "bomb.png"))
+	lib.Must(assets.LoadImage(&SpriteSpeedSmall, "speedSmall.png"))
+	lib.Must(assets.LoadImage(&SpriteSpeedMedium, "speedMedium.png"))
+	lib.Must(assets.LoadImage(&SpriteDamageSmall, "damageSmall.png"))
+	lib.Must(assets.LoadImage(&SpriteDamageMedium, "damageMedium.png"))
+	lib.Must(assets.LoadImage(&SpriteDollar, "dollar.png"))
+	lib.Must(assets.LoadImage(&SpriteDollarOrange, "dollarOrange.png"))
+	lib.Must(assets.LoadImage(&SpriteDollarRed, "dollarRed.png"))
+}
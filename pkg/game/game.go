@@ -3,18 +3,15 @@ package game
 import (
 	"fmt"
 	"image/color"
+	"jamegam/pkg/assets"
 	"jamegam/pkg/audio"
-	"jamegam/pkg/entity"
+	"jamegam/pkg/display"
+	"jamegam/pkg/input"
 	"jamegam/pkg/lib"
-	"jamegam/pkg/pauser"
-	"jamegam/pkg/sprites"
-	"math"
-	"strings"
+	"jamegam/pkg/scene"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 type TileConfig struct {
@@ -23,23 +20,23 @@ type TileConfig struct {
 	scale  int
 }
 
+// fadeDuration is the duration of one half of a fade transition in seconds.
+const fadeDuration = 0.25
+
 type Game struct {
 	tileConfig         TileConfig
 	firstClickHappened bool
-	entities           []entity.Entity
 
-	// Entities
-	grid      *entity.EntityGrid
-	inventory *entity.EntityInventory
+	scenes *scene.Manager
 
-	isMainMenu         bool
-	mainMenuButtonAnim float64
+	// offscreen is the logical screen, reused every frame
+	offscreen *ebiten.Image
 }
 
 // NewGame creates a new Game instance
 func NewGame() *Game {
 	g := &Game{
-		isMainMenu: true,
+		tileConfig: TileConfig{16, 12, 64},
 	}
 	g.Init()
 	return g
@@ -47,149 +44,39 @@ func NewGame() *Game {
 
 // Init initializes the game.
 func (g *Game) Init() {
-	// TODO:
-	audio.Controller.PlayMainMenuOst()
-}
-
-// Called by main menu
-func (g *Game) LateInit() {
-	audio.Controller.PlayOst()
-	g.tileConfig = TileConfig{16, 12, 64}
-	mapDef := `
-pppppppppppppppp
-pppppp........pp
-p....p.pppppp.pp
-p.pp.p..pp....pp
-..pp.pp.pp.ppppp
-pppp..p..p.....p
-ppppp.pp.ppppp.p
-pp....p..p.....p
-pp.pppp.pp.ppppp
-pp..p...pp.p...p
-ppp...pppp...p.p
-pppppppppppppp.p
-`
-	enemyPath := []lib.Vec2I{lib.NewVec2I(14, 12), lib.NewVec2I(14, 11), lib.NewVec2I(14, 10), lib.NewVec2I(14, 9), lib.NewVec2I(13, 9), lib.NewVec2I(12, 9), lib.NewVec2I(12, 10), lib.NewVec2I(11, 10), lib.NewVec2I(10, 10), lib.NewVec2I(10, 9), lib.NewVec2I(10, 8), lib.NewVec2I(10, 7), lib.NewVec2I(11, 7), lib.NewVec2I(12, 7), lib.NewVec2I(13, 7), lib.NewVec2I(14, 7), lib.NewVec2I(14, 6), lib.NewVec2I(14, 5), lib.NewVec2I(13, 5), lib.NewVec2I(12, 5), lib.NewVec2I(11, 5), lib.NewVec2I(10, 5), lib.NewVec2I(10, 4), lib.NewVec2I(10, 3), lib.NewVec2I(11, 3), lib.NewVec2I(12, 3), lib.NewVec2I(13, 3), lib.NewVec2I(13, 2), lib.NewVec2I(13, 1), lib.NewVec2I(12, 1), lib.NewVec2I(11, 1), lib.NewVec2I(10, 1), lib.NewVec2I(9, 1), lib.NewVec2I(8, 1), lib.NewVec2I(7, 1), lib.NewVec2I(6, 1), lib.NewVec2I(6, 2), lib.NewVec2I(6, 3), lib.NewVec2I(7, 3), lib.NewVec2I(7, 4), lib.NewVec2I(7, 5), lib.NewVec2I(8, 5), lib.NewVec2I(8, 6), lib.NewVec2I(8, 7), lib.NewVec2I(7, 7), lib.NewVec2I(7, 8), lib.NewVec2I(7, 9), lib.NewVec2I(6, 9), lib.NewVec2I(5, 9), lib.NewVec2I(5, 10), lib.NewVec2I(4, 10), lib.NewVec2I(3, 10), lib.NewVec2I(3, 9), lib.NewVec2I(2, 9), lib.NewVec2I(2, 8), lib.NewVec2I(2, 7), lib.NewVec2I(3, 7), lib.NewVec2I(4, 7), lib.NewVec2I(5, 7), lib.NewVec2I(5, 6), lib.NewVec2I(5, 5), lib.NewVec2I(4, 5), lib.NewVec2I(4, 4), lib.NewVec2I(4, 3), lib.NewVec2I(4, 2), lib.NewVec2I(3, 2), lib.NewVec2I(2, 2), lib.NewVec2I(1, 2), lib.NewVec2I(1, 3), lib.NewVec2I(1, 4), lib.NewVec2I(0, 4), lib.NewVec2I(-1, 4)}
-
-	mapDef = strings.TrimSpace(mapDef) // remove leading and trailing whitespace
-	g.grid = entity.NewEntityGrid(g.tileConfig.width, g.tileConfig.height, g.tileConfig.scale, mapDef, enemyPath)
-	g.AddEntity(g.grid)
-	g.inventory = entity.NewEntityInventory(g.tileConfig.scale, g.grid)
-	g.AddEntity(g.inventory)
+	g.scenes = scene.NewManager(NewSceneMainMenu(g))
 }
 
 // Update is part of the ebiten.Game interface.
 func (g *Game) Update() error {
-
-	if g.isMainMenu {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
-			inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
-			inpututil.IsKeyJustPressed(ebiten.KeySpace) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) ||
-			inpututil.IsKeyJustPressed(ebiten.KeyW) {
-			audio.Controller.StopMainMenuOst()
-			g.isMainMenu = false
-			g.LateInit()
-		}
-		return nil
-	}
-
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		pauser.IsPaused = !pauser.IsPaused
-	}
 	specialUpdate(g)
-	if !pauser.IsPaused {
-		for _, entity := range g.entities {
-			if err := entity.Update(g); err != nil {
-				return err
-			}
-		}
-	} else {
-		// TODO: pause menu buttons
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			x, y := ebiten.CursorPosition()
-			// vector.DrawFilledRect(fakeScreen, 32+312, 20+300, 336, 88, color.RGBA{0, 0, 0, 100}, false)
-			// vector.DrawFilledRect(fakeScreen, 32+312, 136+300, 336, 88, color.RGBA{0, 0, 0, 100}, false)
-			if x > 312+32 && x < 312+32+336 && y > 300+20 && y < 300+20+88 {
-				g.inventory.RestartGame()
-			}
-			if x > 312+32 && x < 312+32+336 && y > 300+136 && y < 300+136+88 {
-				audio.Controller.ToggleMute()
-			}
-
-		}
-	}
-	return nil
+	input.Update()
+	assets.Update(lib.Dt())
+	audio.Controller.Update(lib.Dt())
+	return g.scenes.Update()
 }
 
 // Draw is part of the ebiten.Game interface.
 func (g *Game) Draw(screen *ebiten.Image) {
-	fakeScreen := ebiten.NewImage(1024, 1024)
+	if g.offscreen == nil {
+		g.offscreen = ebiten.NewImage(display.Width, display.Height)
+	}
 	screen.Fill(color.Black)
-	fakeScreen.Fill(color.Black)
-
-	if !g.isMainMenu {
-		for _, entity := range g.entities {
-			entity.Draw(fakeScreen)
-		}
-
-		if pauser.IsPaused {
-			vector.DrawFilledRect(fakeScreen, 0, 0, 2000, 2000, color.RGBA{0, 0, 0, 100}, false)
-			geom := ebiten.GeoM{}
-			geom.Translate(312, 300)
-			fakeScreen.DrawImage(sprites.SpritePauseMenu, &ebiten.DrawImageOptions{
-				GeoM: geom,
-			})
-			fakeScreen.DrawImage(sprites.SpriteTutorial, &ebiten.DrawImageOptions{})
+	g.offscreen.Fill(color.Black)
 
-			// Button hitboxes
-			// vector.DrawFilledRect(fakeScreen, 32+312, 20+300, 336, 88, color.RGBA{0, 0, 0, 100}, false)
-			// vector.DrawFilledRect(fakeScreen, 32+312, 136+300, 336, 88, color.RGBA{0, 0, 0, 100}, false)
-		}
-
-	} else {
-		geom := ebiten.GeoM{}
-		geom.Translate(0, math.Sin(g.mainMenuButtonAnim)*5)
-		g.mainMenuButtonAnim += 1 * lib.Dt()
-		fakeScreen.DrawImage(sprites.SpriteMainMenu, &ebiten.DrawImageOptions{})
-		fakeScreen.DrawImage(sprites.SpriteMainMenuButton, &ebiten.DrawImageOptions{
-			GeoM: geom,
-		})
-	}
+	g.scenes.Draw(g.offscreen)
 
-	ebitenutil.DebugPrint(fakeScreen, fmt.Sprintf("dt: %f", lib.Dt()))
-	geom := ebiten.GeoM{}
-	// geom.Translate(20, 20)
-	screen.DrawImage(fakeScreen, &ebiten.DrawImageOptions{
-		GeoM: geom,
-	})
+	ebitenutil.DebugPrint(g.offscreen, fmt.Sprintf("dt: %f", lib.Dt()))
+	display.Draw(screen, g.offscreen)
 }
 
 // Layout is part of the ebiten.Game interface.
+// The screen has the size of the window in device pixels, the game itself is
+// drawn at a fixed logical size and scaled onto it, see display.Draw.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	// return (g.tileConfig.width)*g.tileConfig.scale + 40, (g.tileConfig.height+2)*g.tileConfig.scale + 40
-	return (outsideWidth), (outsideHeight)
-}
-
-// AddEntity adds an entity to the game
-func (g *Game) AddEntity(e entity.Entity) {
-	e.Init(g)
-	g.entities = append(g.entities, e)
-}
-
-// RemoveEntity removes an entity from the game
-func (g *Game) RemoveEntity(e entity.Entity) {
-	for i, entity := range g.entities {
-		if entity == e {
-			entity.Deinit(g)
-			g.entities = append(g.entities[:i], g.entities[i+1:]...)
-			return
-		}
-	}
+	deviceScale := ebiten.Monitor().DeviceScaleFactor()
+	display.Resize(float64(outsideWidth)*deviceScale, float64(outsideHeight)*deviceScale)
+	return display.ScreenSize()
 }
 
 // specialUpdate is a part of update that does not contain game specific logic,
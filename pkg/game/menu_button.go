@@ -0,0 +1,108 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/ui"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// menuButton is a simple clickable text button used by the menu scenes.
+type menuButton struct {
+	label      string // message key
+	x, y, w, h int
+}
+
+func (b menuButton) contains(x, y int) bool {
+	return x >= b.x && x < b.x+b.w && y >= b.y && y < b.y+b.h
+}
+
+func (b menuButton) draw(screen *ebiten.Image) {
+	b.drawText(screen, i18n.T(b.label))
+}
+
+// drawValue draws the button with the current value after its label.
+func (b menuButton) drawValue(screen *ebiten.Image, value string) {
+	b.drawText(screen, fmt.Sprintf("%s: %s", i18n.T(b.label), value))
+}
+
+func (b menuButton) drawText(screen *ebiten.Image, txt string) {
+	vector.DrawFilledRect(screen, float32(b.x), float32(b.y), float32(b.w), float32(b.h), color.RGBA{60, 60, 60, 255}, false)
+	vector.StrokeRect(screen, float32(b.x), float32(b.y), float32(b.w), float32(b.h), 3, color.RGBA{100, 255, 100, 255}, false)
+	drawBoxLabel(screen, txt, b.x, b.y, b.w, b.h)
+}
+
+// drawBoxLabel draws txt vertically centered in the box, following the text
+// scale but shrunk to fit where needed.
+func drawBoxLabel(screen *ebiten.Image, txt string, x, y, w, h int) {
+	size := ui.FitFontSize(txt, image.Pt(w-40, h-8))
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x+20), float64(y+h/2))
+	op.SecondaryAlign = text.AlignCenter
+	text.Draw(screen, txt, i18n.Face(size), op)
+}
+
+// translated returns a label func for ui buttons, so their text follows
+// language changes made in the settings.
+func translated(key string, args ...any) func() string {
+	return func() string {
+		return i18n.T(key, args...)
+	}
+}
+
+// drawLines draws the given lines of text below each other into rect,
+// following the text scale but shrunk to fit where needed.
+func drawLines(screen *ebiten.Image, lines []string, rect image.Rectangle) {
+	txt := strings.Join(lines, "\n")
+	size := ui.FitFontSize(txt, rect.Size())
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	op.LineSpacing = size * ui.LineSpacing
+	text.Draw(screen, txt, i18n.Face(size), op)
+}
+
+// drawCentered draws the lines of txt centered on the point x, y.
+func drawCentered(screen *ebiten.Image, face text.Face, txt string, x, y float64, clr color.Color) {
+	metrics := face.Metrics()
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(clr)
+	op.LineSpacing = metrics.HAscent + metrics.HDescent + metrics.HLineGap
+	op.PrimaryAlign = text.AlignCenter
+	op.SecondaryAlign = text.AlignCenter
+	text.Draw(screen, txt, face, op)
+}
+
+// menuSlider is a horizontal slider for values between 0 and 1.
+type menuSlider struct {
+	label      string // message key
+	x, y, w, h int
+}
+
+func (s menuSlider) contains(x, y int) bool {
+	return x >= s.x && x < s.x+s.w && y >= s.y && y < s.y+s.h
+}
+
+// valueAt returns the slider value for the given cursor x position.
+func (s menuSlider) valueAt(x int) float64 {
+	return min(1, max(0, float64(x-s.x)/float64(s.w)))
+}
+
+func (s menuSlider) draw(screen *ebiten.Image, value float64) {
+	s.drawValue(screen, value, fmt.Sprintf("%d%%", int(value*100)))
+}
+
+// drawValue draws the slider filled to the given fraction, showing the value
+// text after the label.
+func (s menuSlider) drawValue(screen *ebiten.Image, fraction float64, value string) {
+	vector.DrawFilledRect(screen, float32(s.x), float32(s.y), float32(s.w), float32(s.h), color.RGBA{60, 60, 60, 255}, false)
+	vector.DrawFilledRect(screen, float32(s.x), float32(s.y), float32(float64(s.w)*fraction), float32(s.h), color.RGBA{70, 160, 70, 255}, false)
+	vector.StrokeRect(screen, float32(s.x), float32(s.y), float32(s.w), float32(s.h), 3, color.RGBA{100, 255, 100, 255}, false)
+	drawBoxLabel(screen, fmt.Sprintf("%s: %s", i18n.T(s.label), value), s.x, s.y, s.w, s.h)
+}
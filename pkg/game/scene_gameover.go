@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/display"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/notify"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/towers"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var _ scene.Scene = &SceneGameOver{}
+
+var (
+	gameOverRetryButton    = menuButton{"menu.retry", 312, 760, 180, 60}
+	gameOverMainMenuButton = menuButton{"menu.main_menu", 532, 760, 180, 60}
+)
+
+// SceneGameOver shows the run statistics after the player has lost or won.
+type SceneGameOver struct {
+	game     *Game
+	gameplay *SceneGameplay
+}
+
+func NewSceneGameOver(g *Game, gameplay *SceneGameplay) *SceneGameOver {
+	return &SceneGameOver{game: g, gameplay: gameplay}
+}
+
+func (s *SceneGameOver) Enter(m *scene.Manager) {
+	audio.Controller.SetMusicState(audio.MusicCalm)
+}
+
+func (s *SceneGameOver) Exit(m *scene.Manager) {
+}
+
+func (s *SceneGameOver) Update(m *scene.Manager) error {
+	notify.Default.Update(lib.Dt()) // the toasts of the game below keep fading
+
+	retry := input.JustPressed(input.ActionConfirm)
+	mainMenu := false
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := display.CursorPosition()
+		retry = retry || gameOverRetryButton.contains(x, y)
+		mainMenu = gameOverMainMenuButton.contains(x, y)
+	}
+
+	if retry {
+		audio.Controller.PlayUI("click")
+		s.gameplay.Restart()
+		m.Pop()
+	} else if mainMenu {
+		audio.Controller.PlayUI("click")
+		m.FadeTo(fadeDuration, func() {
+			m.Replace(NewSceneMainMenu(s.game))
+		})
+	}
+	return nil
+}
+
+func (s *SceneGameOver) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, 2000, 2000, color.RGBA{0, 0, 0, 180}, false)
+
+	inventory := s.gameplay.inventory
+	stats := inventory.GetRunStats()
+	title := i18n.T("gameover.title")
+	if inventory.IsVictory() {
+		title = i18n.T("gameover.victory")
+	}
+	mode := i18n.T("gameover.normal")
+	if stats.Endless {
+		mode = i18n.T("menu.endless")
+	}
+
+	lines := []string{
+		title,
+		"",
+		i18n.T("gameover.mode", mode),
+		i18n.T("gameover.waves", stats.WavesSurvived),
+		i18n.T("gameover.killed", stats.EnemiesKilled),
+		i18n.T("gameover.leaked", stats.EnemiesLeaked),
+		i18n.T("gameover.earned", stats.CurrencyEarned),
+		i18n.T("gameover.spent", stats.CurrencySpent),
+		"",
+		i18n.T("gameover.kills_per_tower"),
+	}
+	for towerType := towers.TowerTypeBasic; towerType <= towers.TowerTypeSuper; towerType++ {
+		if towerType == towers.TowerTypeCash {
+			continue // the mana tower does not deal damage
+		}
+		lines = append(lines, fmt.Sprintf("  %v: %d", towerType, stats.KillsByTower[towerType]))
+	}
+	lines = append(lines, "", i18n.T("gameover.score", stats.Total()))
+	drawLines(screen, lines, image.Rect(312, 120, 1012, 740))
+
+	gameOverRetryButton.draw(screen)
+	gameOverMainMenuButton.draw(screen)
+}
+
+func (s *SceneGameOver) IsOverlay() bool {
+	return true
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"jamegam/pkg/audio"
+	"jamegam/pkg/camera"
+	"jamegam/pkg/display"
+	"jamegam/pkg/entity"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/notify"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/settings"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ scene.Scene = &SceneGameplay{}
+var _ scene.Pausable = &SceneGameplay{}
+var _ entity.EntitySpawner = &SceneGameplay{}
+
+// mapVictoryWave is the number of waves that have to be survived to win the
+// map outside of endless mode.
+const mapVictoryWave = 30
+
+// SceneGameplay runs the actual tower defense game.
+type SceneGameplay struct {
+	game     *Game
+	endless  bool
+	entities []entity.Entity
+
+	// Entities
+	grid      *entity.EntityGrid
+	inventory *entity.EntityInventory
+
+	// world is the buffer the world is drawn into before the camera shows it.
+	world *ebiten.Image
+
+	lastCursorX, lastCursorY int
+}
+
+func NewSceneGameplay(g *Game, endless bool) *SceneGameplay {
+	return &SceneGameplay{
+		game:    g,
+		endless: endless,
+	}
+}
+
+func (s *SceneGameplay) Enter(m *scene.Manager) {
+	audio.Controller.SetMusicState(audio.MusicCalm)
+
+	tileConfig := s.game.tileConfig
+	worldW, worldH := tileConfig.width*tileConfig.scale, tileConfig.height*tileConfig.scale
+	if s.world == nil {
+		s.world = ebiten.NewImage(worldW, worldH)
+	}
+	camera.Main = camera.New(0, 0, float32(worldW), float32(worldH), float32(worldW), float32(worldH))
+	s.grid = entity.NewEntityGrid(tileConfig.width, tileConfig.height, tileConfig.scale)
+	s.AddEntity(s.grid)
+	s.inventory = entity.NewEntityInventory(tileConfig.scale, s.grid)
+	s.inventory.SetEndlessMode(s.endless)
+	if !s.endless {
+		s.inventory.SetVictoryWave(mapVictoryWave)
+	}
+	s.AddEntity(s.inventory)
+}
+
+func (s *SceneGameplay) Exit(m *scene.Manager) {
+	for len(s.entities) > 0 {
+		s.RemoveEntity(s.entities[0])
+	}
+}
+
+func (s *SceneGameplay) Update(m *scene.Manager) error {
+	notify.Default.Update(lib.Dt())
+
+	if s.inventory.IsGameOver() || s.inventory.IsVictory() {
+		m.Push(NewSceneGameOver(s.game, s))
+		return nil
+	}
+
+	if input.JustPressed(input.ActionMenu) {
+		m.Push(NewScenePause(s.game, s))
+		return nil
+	}
+
+	if input.JustPressed(input.ActionHistory) {
+		audio.Controller.PlayUI("click")
+		notify.Default.ToggleHistory()
+	}
+	lib.TimeScale = settings.Current.GameSpeed * s.inventory.FastForward()
+	s.updateCamera()
+	for _, entity := range s.entities {
+		if err := entity.Update(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateCamera zooms with the mouse wheel and pans by dragging with the
+// middle mouse button.
+func (s *SceneGameplay) updateCamera() {
+	camera.Main.Update(lib.Dt())
+
+	x, y := display.CursorPosition()
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 && notify.Default.HistoryOpen() {
+		notify.Default.ScrollHistory(int(math.Copysign(1, wheelY))) // the open log takes the wheel
+	} else if wheelY != 0 && camera.Main.InView(x, y) {
+		camera.Main.ZoomAt(float32(math.Pow(1.1, wheelY)), x, y)
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		camera.Main.Pan(float32(x-s.lastCursorX), float32(y-s.lastCursorY))
+	}
+	s.lastCursorX, s.lastCursorY = x, y
+}
+
+func (s *SceneGameplay) Draw(screen *ebiten.Image) {
+	s.world.Clear()
+	for _, e := range s.entities {
+		if drawer, ok := e.(entity.WorldDrawer); ok {
+			drawer.DrawWorld(s.world)
+		}
+	}
+	screen.DrawImage(s.world, &ebiten.DrawImageOptions{GeoM: camera.Main.GeoM()})
+
+	for _, entity := range s.entities {
+		entity.Draw(screen)
+	}
+}
+
+// SetPaused settles the camera while a menu covers the game, so the frozen
+// world below it does not hang mid shake, and restarts the drag on resuming
+// so the camera does not jump by the cursor moves made in the menu.
+func (s *SceneGameplay) SetPaused(paused bool) {
+	if paused {
+		camera.Main.StopShake()
+		return
+	}
+	s.lastCursorX, s.lastCursorY = display.CursorPosition()
+}
+
+func (s *SceneGameplay) IsOverlay() bool {
+	return false
+}
+
+// Restart starts the run from scratch.
+func (s *SceneGameplay) Restart() {
+	s.inventory.RestartGame()
+	camera.Main.Reset()
+}
+
+// AddEntity adds an entity to the game
+func (s *SceneGameplay) AddEntity(e entity.Entity) {
+	e.Init(s)
+	s.entities = append(s.entities, e)
+}
+
+// RemoveEntity removes an entity from the game
+func (s *SceneGameplay) RemoveEntity(e entity.Entity) {
+	for i, entity := range s.entities {
+		if entity == e {
+			entity.Deinit(s)
+			s.entities = append(s.entities[:i], s.entities[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var _ scene.Scene = &SceneKeybindings{}
+
+// SceneKeybindings lets the player rebind the keys of all input actions.
+type SceneKeybindings struct {
+	game *Game
+	ui   *ui.Root
+
+	// waiting is the action whose slot is rebound by the next key press.
+	waiting     input.Action
+	waitingSlot int
+
+	message string
+	dirty   bool
+}
+
+func NewSceneKeybindings(g *Game) *SceneKeybindings {
+	return &SceneKeybindings{game: g}
+}
+
+func (s *SceneKeybindings) Enter(m *scene.Manager) {
+	s.ui = ui.NewRoot()
+	for i, action := range input.Actions {
+		y := 130 + i*34
+		label := ui.NewLabel(image.Pt(212, y+3), action.String())
+		label.Rect.Max = image.Pt(482, y+30)
+		s.ui.Add(label)
+		for slot := range input.SlotsPerAction {
+			rect := image.Rect(492+slot*190, y, 492+slot*190+180, y+30)
+			button := ui.NewButton(rect, "", func() {
+				s.waiting = action
+				s.waitingSlot = slot
+				s.message = i18n.T("keybindings.press", action)
+			})
+			button.LabelFunc = func() string {
+				return s.slotLabel(action, slot)
+			}
+			s.ui.Add(button)
+		}
+	}
+	s.ui.Add(
+		ui.NewButton(image.Rect(212, 870, 502, 920), i18n.T("keybindings.reset"), func() {
+			input.Current = input.DefaultBindings()
+			s.message = i18n.T("keybindings.reset_done")
+			s.dirty = true
+		}),
+		ui.NewButton(image.Rect(522, 870, 862, 920), i18n.T("menu.back"), m.Pop),
+	)
+	if len(input.Current.Conflicts()) > 0 {
+		s.message = i18n.T("keybindings.conflicts")
+	}
+}
+
+func (s *SceneKeybindings) Exit(m *scene.Manager) {
+	if s.dirty {
+		input.Save()
+	}
+}
+
+// slotLabel returns the name of the key in the slot, marking keys that are
+// bound to several actions.
+func (s *SceneKeybindings) slotLabel(action input.Action, slot int) string {
+	if s.waiting == action && s.waitingSlot == slot {
+		return "..."
+	}
+	key, ok := input.Current.Key(action, slot)
+	if !ok {
+		return "-"
+	}
+	if _, conflict := input.Current.Conflicts()[key]; conflict {
+		return input.KeyName(key) + " (!)"
+	}
+	return input.KeyName(key)
+}
+
+func (s *SceneKeybindings) Update(m *scene.Manager) error {
+	if s.waiting != "" {
+		s.updateWaiting()
+		return nil
+	}
+	if input.JustPressed(input.ActionMenu) {
+		m.Pop()
+		return nil
+	}
+	s.ui.Update(lib.Dt())
+	return nil
+}
+
+// updateWaiting binds the next pressed key to the slot being rebound.
+func (s *SceneKeybindings) updateWaiting() {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		s.waiting = ""
+		s.message = ""
+		return
+	}
+	keys := inpututil.AppendJustPressedKeys(nil)
+	if len(keys) == 0 {
+		return
+	}
+	key := keys[0]
+	action := s.waiting
+	s.waiting = ""
+	s.dirty = true
+	audio.Controller.PlayUI("click")
+
+	if key == ebiten.KeyBackspace {
+		input.Current.Unbind(action, s.waitingSlot)
+		s.message = i18n.T("keybindings.unbound", action)
+	} else if previous := input.Current.Bind(action, s.waitingSlot, key); previous != "" {
+		s.message = i18n.T("keybindings.moved", input.KeyName(key), previous, input.KeyNames(previous))
+	} else {
+		s.message = i18n.T("keybindings.bound", action, input.KeyNames(action))
+	}
+	if len(input.Current[input.ActionMenu]) == 0 {
+		s.message += " " + i18n.T("keybindings.no_key", input.ActionMenu)
+	}
+}
+
+func (s *SceneKeybindings) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, 2000, 2000, color.RGBA{0, 0, 0, 220}, false)
+	drawLines(screen, []string{i18n.T("keybindings.title"), s.message}, image.Rect(212, 70, 862, 128))
+	s.ui.Draw(screen)
+}
+
+func (s *SceneKeybindings) IsOverlay() bool {
+	return true
+}
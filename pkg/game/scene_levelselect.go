@@ -0,0 +1,81 @@
+package game
+
+import (
+	"image"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/sprites"
+	"jamegam/pkg/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var _ scene.Scene = &SceneLevelSelect{}
+
+// SceneLevelSelect lets the player choose between the normal and the endless
+// mode.
+type SceneLevelSelect struct {
+	game *Game
+	ui   *ui.Root
+}
+
+func NewSceneLevelSelect(g *Game) *SceneLevelSelect {
+	return &SceneLevelSelect{game: g}
+}
+
+func (s *SceneLevelSelect) Enter(m *scene.Manager) {
+	audio.Controller.SetMusicState(audio.MusicMenu)
+	rects := ui.Column(image.Pt(362, 380), image.Pt(300, 60), 20, 4)
+	normal := ui.NewButton(rects[0], "", func() {
+		m.FadeTo(fadeDuration, func() {
+			m.Replace(NewSceneGameplay(s.game, false))
+		})
+	})
+	normal.LabelFunc = translated("menu.normal", mapVictoryWave)
+	endless := ui.NewButton(rects[1], "", func() {
+		m.FadeTo(fadeDuration, func() {
+			m.Replace(NewSceneGameplay(s.game, true))
+		})
+	})
+	endless.LabelFunc = translated("menu.endless")
+	settings := ui.NewButton(rects[2], "", func() {
+		m.Push(NewSceneSettings(s.game))
+	})
+	settings.LabelFunc = translated("menu.settings")
+	back := ui.NewButton(rects[3], "", func() {
+		s.back(m)
+	})
+	back.LabelFunc = translated("menu.back")
+	s.ui = ui.NewRoot(normal, endless, settings, back)
+	s.ui.Focus(normal) // so keyboard and gamepad players can start right away
+}
+
+func (s *SceneLevelSelect) Exit(m *scene.Manager) {
+}
+
+func (s *SceneLevelSelect) back(m *scene.Manager) {
+	m.FadeTo(fadeDuration, func() {
+		m.Replace(NewSceneMainMenu(s.game))
+	})
+}
+
+func (s *SceneLevelSelect) Update(m *scene.Manager) error {
+	if input.JustPressed(input.ActionMenu) {
+		audio.Controller.PlayUI("click")
+		s.back(m)
+		return nil
+	}
+	s.ui.Update(lib.Dt())
+	return nil
+}
+
+func (s *SceneLevelSelect) Draw(screen *ebiten.Image) {
+	screen.DrawImage(sprites.SpriteMainMenu, &ebiten.DrawImageOptions{})
+	s.ui.Draw(screen)
+}
+
+func (s *SceneLevelSelect) IsOverlay() bool {
+	return false
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/display"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/settings"
+	"jamegam/pkg/sprites"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+var _ scene.Scene = &SceneMainMenu{}
+
+// menuShadowColor is the outline color of the menu art, used for text
+// shadows on it.
+var menuShadowColor = color.RGBA{46, 34, 47, 255}
+
+// SceneMainMenu is the title screen, any input leads to the level select.
+type SceneMainMenu struct {
+	game       *Game
+	buttonAnim float64
+}
+
+func NewSceneMainMenu(g *Game) *SceneMainMenu {
+	return &SceneMainMenu{game: g}
+}
+
+func (s *SceneMainMenu) Enter(m *scene.Manager) {
+	audio.Controller.SetMusicState(audio.MusicMenu)
+}
+
+func (s *SceneMainMenu) Exit(m *scene.Manager) {
+}
+
+func (s *SceneMainMenu) Update(m *scene.Manager) error {
+	s.buttonAnim += 1 * lib.Dt()
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
+		inpututil.IsKeyJustPressed(ebiten.KeySpace) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyW) ||
+		input.JustPressed(input.ActionConfirm) {
+		audio.Controller.PlayUI("click")
+		m.FadeTo(fadeDuration, func() {
+			m.Replace(NewSceneLevelSelect(s.game))
+		})
+	}
+	return nil
+}
+
+func (s *SceneMainMenu) Draw(screen *ebiten.Image) {
+	y := 405.0
+	if !settings.Current.ReducedMotion {
+		y += math.Sin(s.buttonAnim) * 5
+	}
+	screen.DrawImage(sprites.SpriteMainMenu, &ebiten.DrawImageOptions{})
+	face := i18n.Face(56)
+	drawCentered(screen, face, i18n.T("menu.start_game"), display.Width/2+4, y+4, menuShadowColor)
+	drawCentered(screen, face, i18n.T("menu.start_game"), display.Width/2, y, color.White)
+}
+
+func (s *SceneMainMenu) IsOverlay() bool {
+	return false
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/lib"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/sprites"
+	"jamegam/pkg/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var _ scene.Scene = &ScenePause{}
+
+// ScenePause is shown on top of the gameplay while the game is paused.
+type ScenePause struct {
+	game     *Game
+	gameplay *SceneGameplay
+	ui       *ui.Root
+
+	restart, mute *ui.IconButton
+}
+
+// tutorialNotes are the hints drawn next to the arrows of the tutorial
+// sprite, centered on their position.
+var tutorialNotes = []struct {
+	key  string
+	x, y float64
+}{
+	{"tutorial.cash_out", 540, 160},
+	{"tutorial.upgrade", 160, 432},
+	{"tutorial.hat", 560, 645},
+}
+
+func NewScenePause(g *Game, gameplay *SceneGameplay) *ScenePause {
+	return &ScenePause{game: g, gameplay: gameplay}
+}
+
+func (s *ScenePause) Enter(m *scene.Manager) {
+	menu := ui.NewPanel(image.Rect(312, 300, 312+sprites.SpritePauseMenu.Bounds().Dx(), 300+sprites.SpritePauseMenu.Bounds().Dy()))
+	menu.Image = sprites.SpritePauseMenu
+
+	// The restart and mute buttons are drawn by the pause menu sprite, their
+	// labels by Draw.
+	const spriteBorderX, spriteBorderY, spriteButtonH, spriteButtonGap = 32, 20, 88, 28
+	spriteButtons := ui.Column(menu.Rect.Min.Add(image.Pt(spriteBorderX, spriteBorderY)),
+		image.Pt(menu.Rect.Dx()-2*spriteBorderX, spriteButtonH), spriteButtonGap, 2)
+	s.restart = ui.NewIconButton(spriteButtons[0], nil, nil, func() {
+		s.gameplay.Restart()
+		m.Pop()
+	})
+	s.mute = ui.NewIconButton(spriteButtons[1], nil, nil, audio.Controller.ToggleMute)
+
+	// The other buttons go in a row below the menu.
+	below := ui.Grid{Origin: image.Pt(menu.Rect.Min.X, menu.Rect.Max.Y+16), Cell: image.Pt(190, 60), Gap: image.Pt(20, 0)}
+	settings := ui.NewButton(below.At(0, 0), "", func() {
+		m.Push(NewSceneSettings(s.game))
+	})
+	settings.LabelFunc = translated("menu.settings")
+	mainMenu := ui.NewButton(below.At(1, 0), "", func() {
+		m.FadeTo(fadeDuration, func() {
+			m.Replace(NewSceneMainMenu(s.game))
+		})
+	})
+	mainMenu.LabelFunc = translated("menu.main_menu")
+	menu.Items = append(menu.Items, s.restart, s.mute, settings, mainMenu)
+	s.ui = ui.NewRoot(menu)
+}
+
+func (s *ScenePause) Exit(m *scene.Manager) {
+}
+
+func (s *ScenePause) Update(m *scene.Manager) error {
+	if input.JustPressed(input.ActionMenu) {
+		m.Pop()
+		return nil
+	}
+	s.ui.Update(lib.Dt())
+	return nil
+}
+
+func (s *ScenePause) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, 2000, 2000, color.RGBA{0, 0, 0, 100}, false)
+	screen.DrawImage(sprites.SpriteTutorial, &ebiten.DrawImageOptions{})
+	noteFace := i18n.Face(28)
+	for _, note := range tutorialNotes {
+		drawCentered(screen, noteFace, i18n.T(note.key), note.x, note.y, color.White)
+	}
+
+	s.ui.Draw(screen)
+	mute := "pause.mute"
+	if audio.Controller.IsMuted() {
+		mute = "pause.unmute"
+	}
+	buttonFace := i18n.Face(44)
+	drawButtonLabel(screen, buttonFace, i18n.T("pause.restart"), s.restart.Rect)
+	drawButtonLabel(screen, buttonFace, i18n.T(mute), s.mute.Rect)
+}
+
+// drawButtonLabel draws a label with a shadow on a button of the pause menu
+// sprite.
+func drawButtonLabel(screen *ebiten.Image, face text.Face, label string, rect image.Rectangle) {
+	x, y := float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2)
+	drawCentered(screen, face, label, x+4, y+4, menuShadowColor)
+	drawCentered(screen, face, label, x, y, color.White)
+}
+
+func (s *ScenePause) IsOverlay() bool {
+	return true
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/display"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/scene"
+	"jamegam/pkg/settings"
+	"log"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var _ scene.Scene = &SceneSettings{}
+
+var (
+	settingsMasterSlider = menuSlider{"settings.master_volume", 92, 180, 400, 50}
+	settingsMusicSlider  = menuSlider{"settings.music_volume", 92, 240, 400, 50}
+	settingsSfxSlider    = menuSlider{"settings.sfx_volume", 92, 300, 400, 50}
+	settingsUiSlider     = menuSlider{"settings.ui_volume", 92, 360, 400, 50}
+
+	settingsLanguageButton    = menuButton{"settings.language", 92, 440, 400, 50}
+	settingsFullscreenButton  = menuButton{"settings.fullscreen", 92, 500, 400, 50}
+	settingsWindowScaleButton = menuButton{"settings.window_scale", 92, 560, 400, 50}
+	settingsIntegerButton     = menuButton{"settings.integer_scaling", 92, 620, 400, 50}
+	settingsRangeButton       = menuButton{"settings.range_indicator", 92, 680, 400, 50}
+	settingsHealthBarsButton  = menuButton{"settings.health_bars", 92, 740, 400, 50}
+
+	// Accessibility
+	settingsGameSpeedSlider     = menuSlider{"settings.game_speed", 532, 180, 400, 50}
+	settingsColorblindButton    = menuButton{"settings.colorblind", 532, 260, 400, 50}
+	settingsHighContrastButton  = menuButton{"settings.high_contrast", 532, 320, 400, 50}
+	settingsTextScaleButton     = menuButton{"settings.text_scale", 532, 380, 400, 50}
+	settingsReducedMotionButton = menuButton{"settings.reduced_motion", 532, 440, 400, 50}
+	settingsScreenshakeButton   = menuButton{"settings.screenshake", 532, 500, 400, 50}
+
+	settingsKeybindingsButton = menuButton{"settings.keybindings", 92, 860, 400, 50}
+	settingsBackButton        = menuButton{"menu.back", 532, 860, 400, 50}
+)
+
+// SceneSettings lets the player change and persist the game options.
+type SceneSettings struct {
+	game  *Game
+	dirty bool // a slider was changed and the settings have to be saved
+}
+
+func NewSceneSettings(g *Game) *SceneSettings {
+	return &SceneSettings{game: g}
+}
+
+func (s *SceneSettings) Enter(m *scene.Manager) {
+}
+
+func (s *SceneSettings) Exit(m *scene.Manager) {
+	if s.dirty {
+		settings.Save()
+	}
+}
+
+func (s *SceneSettings) Update(m *scene.Manager) error {
+	if input.JustPressed(input.ActionMenu) {
+		m.Pop()
+		return nil
+	}
+
+	x, y := display.CursorPosition()
+	current := &settings.Current
+
+	// Sliders can be dragged, so they use the held mouse button.
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if settingsMasterSlider.contains(x, y) {
+			current.MasterVolume = settingsMasterSlider.valueAt(x)
+			s.dirty = true
+		} else if settingsMusicSlider.contains(x, y) {
+			current.MusicVolume = settingsMusicSlider.valueAt(x)
+			s.dirty = true
+		} else if settingsSfxSlider.contains(x, y) {
+			current.SfxVolume = settingsSfxSlider.valueAt(x)
+			s.dirty = true
+		} else if settingsUiSlider.contains(x, y) {
+			current.UiVolume = settingsUiSlider.valueAt(x)
+			s.dirty = true
+		} else if settingsGameSpeedSlider.contains(x, y) {
+			current.GameSpeed = settings.MinGameSpeed + (1-settings.MinGameSpeed)*settingsGameSpeedSlider.valueAt(x)
+			s.dirty = true
+		}
+		audio.Controller.SetVolumes(current.MasterVolume, current.MusicVolume, current.SfxVolume, current.UiVolume)
+	}
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && s.dirty {
+		audio.Controller.Play("basic_tower_shoot") // preview the sfx volume
+		settings.Save()
+		s.dirty = false
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return nil
+	}
+	changed := true
+	if settingsLanguageButton.contains(x, y) {
+		current.Language = cycle(i18n.Languages, current.Language)
+		if err := i18n.SetLanguage(current.Language); err != nil {
+			log.Printf("Could not switch language: %v", err)
+		}
+	} else if settingsFullscreenButton.contains(x, y) {
+		current.Fullscreen = !current.Fullscreen
+		ebiten.SetFullscreen(current.Fullscreen)
+	} else if settingsWindowScaleButton.contains(x, y) {
+		current.WindowScale = cycle(settings.WindowScales, current.WindowScale)
+		ebiten.SetWindowSize(int(display.Width*current.WindowScale), int(display.Height*current.WindowScale))
+	} else if settingsRangeButton.contains(x, y) {
+		current.RangeIndicator = !current.RangeIndicator
+	} else if settingsScreenshakeButton.contains(x, y) {
+		current.Screenshake = !current.Screenshake
+	} else if settingsHealthBarsButton.contains(x, y) {
+		current.HealthBars = !current.HealthBars
+	} else if settingsIntegerButton.contains(x, y) {
+		current.IntegerScaling = !current.IntegerScaling
+	} else if settingsColorblindButton.contains(x, y) {
+		current.Colorblind = cycle(settings.ColorblindModes, current.Colorblind)
+	} else if settingsHighContrastButton.contains(x, y) {
+		current.HighContrast = !current.HighContrast
+	} else if settingsTextScaleButton.contains(x, y) {
+		current.TextScale = cycle(settings.TextScales, current.TextScale)
+	} else if settingsReducedMotionButton.contains(x, y) {
+		current.ReducedMotion = !current.ReducedMotion
+	} else if settingsKeybindingsButton.contains(x, y) {
+		changed = false
+		m.Push(NewSceneKeybindings(s.game))
+	} else if settingsBackButton.contains(x, y) {
+		changed = false
+		m.Pop()
+	} else {
+		return nil
+	}
+	audio.Controller.PlayUI("click")
+	if changed {
+		settings.Save()
+	}
+	return nil
+}
+
+// cycle returns the value following the given one in the list, wrapping
+// around to the first.
+func cycle[T comparable](values []T, value T) T {
+	i := slices.Index(values, value)
+	return values[(i+1)%len(values)]
+}
+
+func onOff(value bool) string {
+	if value {
+		return i18n.T("common.on")
+	}
+	return i18n.T("common.off")
+}
+
+func (s *SceneSettings) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, 2000, 2000, color.RGBA{0, 0, 0, 200}, false)
+	drawLines(screen, []string{i18n.T("settings.title")}, image.Rect(92, 140, 492, 178))
+	drawLines(screen, []string{i18n.T("settings.accessibility")}, image.Rect(532, 140, 932, 178))
+
+	current := settings.Current
+	settingsMasterSlider.draw(screen, current.MasterVolume)
+	settingsMusicSlider.draw(screen, current.MusicVolume)
+	settingsSfxSlider.draw(screen, current.SfxVolume)
+	settingsUiSlider.draw(screen, current.UiVolume)
+	settingsGameSpeedSlider.drawValue(screen,
+		(current.GameSpeed-settings.MinGameSpeed)/(1-settings.MinGameSpeed),
+		fmt.Sprintf("%d%%", int(math.Round(current.GameSpeed*100))))
+
+	for _, toggle := range []struct {
+		button menuButton
+		value  string
+	}{
+		{settingsLanguageButton, i18n.Name(current.Language)},
+		{settingsFullscreenButton, onOff(current.Fullscreen)},
+		{settingsWindowScaleButton, fmt.Sprintf("%d%%", int(current.WindowScale*100))},
+		{settingsIntegerButton, onOff(current.IntegerScaling)},
+		{settingsRangeButton, onOff(current.RangeIndicator)},
+		{settingsHealthBarsButton, onOff(current.HealthBars)},
+		{settingsColorblindButton, i18n.T("colorblind." + current.Colorblind)},
+		{settingsHighContrastButton, onOff(current.HighContrast)},
+		{settingsTextScaleButton, fmt.Sprintf("%d%%", int(current.TextScale*100))},
+		{settingsReducedMotionButton, onOff(current.ReducedMotion)},
+		{settingsScreenshakeButton, onOff(current.Screenshake && !current.ReducedMotion)},
+	} {
+		toggle.button.drawValue(screen, toggle.value)
+	}
+	settingsKeybindingsButton.draw(screen)
+	settingsBackButton.draw(screen)
+}
+
+func (s *SceneSettings) IsOverlay() bool {
+	return true
+}
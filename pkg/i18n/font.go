@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+	"log"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+var (
+	fontSources []*text.GoTextFaceSource
+	faces       = map[float64]text.Face{}
+)
+
+// loadFonts loads font.ttf followed by the fallback fonts of all catalogs, so
+// one face can draw every language.
+func loadFonts() {
+	names := []string{"font.ttf"}
+	for _, code := range Languages {
+		for _, name := range catalogs[code].Fonts {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	for i, name := range names {
+		file, err := assets.Open(name)
+		if err != nil && i > 0 {
+			log.Printf("Could not open fallback font: %v", err)
+			continue
+		}
+		lib.Must(err)
+		source, err := text.NewGoTextFaceSource(file)
+		lib.Must(err)
+		fontSources = append(fontSources, source)
+	}
+}
+
+// Face returns a face of the given size which falls back to the fonts of the
+// catalogs for glyphs missing from font.ttf.
+func Face(size float64) text.Face {
+	if face, ok := faces[size]; ok {
+		return face
+	}
+	if fontSources == nil {
+		loadFonts()
+	}
+	var face text.Face = &text.GoTextFace{Source: fontSources[0], Size: size}
+	if len(fontSources) > 1 {
+		all := []text.Face{}
+		for _, source := range fontSources {
+			all = append(all, &text.GoTextFace{Source: source, Size: size})
+		}
+		multi, err := text.NewMultiFace(all...)
+		lib.Must(err)
+		face = multi
+	}
+	faces[size] = face
+	return face
+}
@@ -0,0 +1,158 @@
+// Package i18n translates the player facing texts. Every language has a
+// message catalog lang_<code>.json in the assets, texts missing from a
+// catalog fall back to English.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+	"log"
+)
+
+// Fallback is the language every message exists in.
+const Fallback = "en"
+
+// Languages lists the codes of all languages with a catalog.
+var Languages = []string{"en", "de", "ja"}
+
+// catalog holds the messages of one language.
+type catalog struct {
+	Name     string                     `json:"name"`
+	Fonts    []string                   `json:"fonts"` // fallback fonts for scripts font.ttf lacks
+	Messages map[string]json.RawMessage `json:"messages"`
+
+	messages map[string]message
+}
+
+// message maps plural categories to formats. Messages without plural forms
+// only have "other".
+type message map[string]string
+
+var (
+	catalogs = map[string]*catalog{}
+	current  = Fallback
+)
+
+func init() {
+	for _, code := range Languages {
+		lib.Must(loadCatalog(code))
+		assets.Watch(catalogFile(code), func() error {
+			return loadCatalog(code)
+		})
+	}
+}
+
+func catalogFile(code string) string {
+	return fmt.Sprintf("lang_%s.json", code)
+}
+
+func loadCatalog(code string) error {
+	data, err := assets.ReadFile(catalogFile(code))
+	if err != nil {
+		return err
+	}
+	c, err := parseCatalog(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", catalogFile(code), err)
+	}
+	catalogs[code] = c
+	return nil
+}
+
+func parseCatalog(data []byte) (*catalog, error) {
+	c := &catalog{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	c.messages = make(map[string]message, len(c.Messages))
+	for key, raw := range c.Messages {
+		var plain string
+		if err := json.Unmarshal(raw, &plain); err == nil {
+			c.messages[key] = message{"other": plain}
+			continue
+		}
+		var forms message
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return nil, fmt.Errorf("message %q: %w", key, err)
+		}
+		if _, ok := forms["other"]; !ok {
+			return nil, fmt.Errorf("message %q has no \"other\" form", key)
+		}
+		c.messages[key] = forms
+	}
+	return c, nil
+}
+
+// SetLanguage switches all texts to the language with the given code.
+func SetLanguage(code string) error {
+	if _, ok := catalogs[code]; !ok {
+		return fmt.Errorf("no catalog for language %q", code)
+	}
+	current = code
+	return nil
+}
+
+// Language returns the code of the current language.
+func Language() string {
+	return current
+}
+
+// Name returns the name of the language in that language, e.g. "Deutsch".
+func Name(code string) string {
+	if c, ok := catalogs[code]; ok && c.Name != "" {
+		return c.Name
+	}
+	return code
+}
+
+// pluralCategory returns the plural form the language uses for n. New
+// languages need a case unless they share the English rule.
+func pluralCategory(code string, n int) string {
+	switch code {
+	case "ja":
+		return "other" // no plural forms
+	}
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// lookup returns the format of the message in the current language, falling
+// back to English and then to the key itself.
+func lookup(key, category string) string {
+	for _, code := range []string{current, Fallback} {
+		c, ok := catalogs[code]
+		if !ok {
+			continue
+		}
+		if forms, ok := c.messages[key]; ok {
+			if format, ok := forms[category]; ok {
+				return format
+			}
+			return forms["other"]
+		}
+	}
+	if current != Fallback {
+		log.Printf("Missing message %q", key)
+	}
+	return key
+}
+
+// T returns the translated message, formatted with the args like fmt.Sprintf.
+func T(key string, args ...any) string {
+	format := lookup(key, "other")
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// N returns the plural form of the message matching n. n is the first
+// format argument, followed by args.
+func N(key string, n int, args ...any) string {
+	format := lookup(key, pluralCategory(current, n))
+	return fmt.Sprintf(format, append([]any{n}, args...)...)
+}
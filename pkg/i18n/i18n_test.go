@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"jamegam/pkg/assets"
+	"testing"
+)
+
+func TestParseCatalogPlurals(t *testing.T) {
+	c, err := parseCatalog([]byte(`{"name": "Test", "messages": {
+		"plain": "Hello",
+		"seconds": {"one": "%d second", "other": "%d seconds"}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalogs["test"] = c
+	defer delete(catalogs, "test")
+	if err := SetLanguage("test"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLanguage(Fallback)
+
+	if got := T("plain"); got != "Hello" {
+		t.Errorf("T(plain) = %q", got)
+	}
+	if got := N("seconds", 1); got != "1 second" {
+		t.Errorf("N(seconds, 1) = %q", got)
+	}
+	if got := N("seconds", 3); got != "3 seconds" {
+		t.Errorf("N(seconds, 3) = %q", got)
+	}
+	if got := T("menu.back"); got != catalogs[Fallback].messages["menu.back"]["other"] {
+		t.Errorf("missing message did not fall back to English: %q", got)
+	}
+}
+
+func TestParseCatalogRejectsMissingOther(t *testing.T) {
+	if _, err := parseCatalog([]byte(`{"messages": {"x": {"one": "a"}}}`)); err == nil {
+		t.Error("plural message without other form accepted")
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	for _, c := range []struct {
+		code string
+		n    int
+		want string
+	}{
+		{"en", 1, "one"},
+		{"en", 2, "other"},
+		{"de", 1, "one"},
+		{"ja", 1, "other"},
+	} {
+		if got := pluralCategory(c.code, c.n); got != c.want {
+			t.Errorf("pluralCategory(%s, %d) = %s, want %s", c.code, c.n, got, c.want)
+		}
+	}
+}
+
+func TestFallbackFontsExist(t *testing.T) {
+	for _, code := range Languages {
+		for _, name := range catalogs[code].Fonts {
+			file, err := assets.Open(name)
+			if err != nil {
+				t.Errorf("%s: %v", code, err)
+				continue
+			}
+			file.Close()
+		}
+	}
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, code := range Languages {
+		for key := range catalogs[Fallback].messages {
+			if _, ok := catalogs[code].messages[key]; !ok {
+				t.Errorf("%s is missing %q", code, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GamepadButtons maps actions to buttons of the standard gamepad layout.
+// Unlike the keys they can't be rebound.
+var GamepadButtons = map[Action][]ebiten.StandardGamepadButton{
+	ActionMenu:         {ebiten.StandardGamepadButtonCenterRight},
+	ActionConfirm:      {ebiten.StandardGamepadButtonRightBottom},
+	ActionCancel:       {ebiten.StandardGamepadButtonRightRight},
+	ActionUpgradeLeft:  {ebiten.StandardGamepadButtonRightLeft},
+	ActionUpgradeRight: {ebiten.StandardGamepadButtonRightTop},
+	ActionSell:         {ebiten.StandardGamepadButtonFrontTopLeft},
+	ActionHat:          {ebiten.StandardGamepadButtonFrontTopRight},
+	ActionStartWave:    {ebiten.StandardGamepadButtonCenterLeft},
+	ActionFocusNext:    {ebiten.StandardGamepadButtonFrontBottomRight},
+	ActionCursorUp:     {ebiten.StandardGamepadButtonLeftTop},
+	ActionCursorDown:   {ebiten.StandardGamepadButtonLeftBottom},
+	ActionCursorLeft:   {ebiten.StandardGamepadButtonLeftLeft},
+	ActionCursorRight:  {ebiten.StandardGamepadButtonLeftRight},
+}
+
+// GamepadConflicts returns the gamepad buttons mapped to more than one
+// action, one press of them would trigger all of these actions.
+func GamepadConflicts() map[ebiten.StandardGamepadButton][]Action {
+	return buttonConflicts(GamepadButtons)
+}
+
+func buttonConflicts(buttons map[Action][]ebiten.StandardGamepadButton) map[ebiten.StandardGamepadButton][]Action {
+	byButton := map[ebiten.StandardGamepadButton][]Action{}
+	for _, action := range Actions {
+		for _, button := range buttons[action] {
+			byButton[button] = append(byButton[button], action)
+		}
+	}
+	for button, actions := range byButton {
+		if len(actions) < 2 {
+			delete(byButton, button)
+		}
+	}
+	return byButton
+}
+
+// stickDeadzone is how far the left stick has to be pushed to move the cursor.
+const stickDeadzone = 0.5
+
+// held counts the ticks every action has been held for, 0 if it is released.
+var held = map[Action]int{}
+
+var gamepadIDs []ebiten.GamepadID
+
+// Update polls the keyboard and all gamepads. It has to be called once per
+// tick before any action is checked.
+func Update() {
+	gamepadIDs = ebiten.AppendGamepadIDs(gamepadIDs[:0])
+	for _, action := range Actions {
+		if isDown(action) {
+			held[action]++
+		} else {
+			held[action] = 0
+		}
+	}
+}
+
+// isDown reports whether any input of the action is down right now.
+func isDown(action Action) bool {
+	for _, key := range Current[action] {
+		if ebiten.IsKeyPressed(key) {
+			return true
+		}
+	}
+	for _, id := range gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for _, button := range GamepadButtons[action] {
+			if ebiten.IsStandardGamepadButtonPressed(id, button) {
+				return true
+			}
+		}
+		if stickDown(id, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// stickDown treats the left stick as a d-pad for the cursor actions.
+func stickDown(id ebiten.GamepadID, action Action) bool {
+	x := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+	y := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+	switch action {
+	case ActionCursorUp:
+		return y < -stickDeadzone
+	case ActionCursorDown:
+		return y > stickDeadzone
+	case ActionCursorLeft:
+		return x < -stickDeadzone
+	case ActionCursorRight:
+		return x > stickDeadzone
+	}
+	return false
+}
+
+// GamepadConnected reports whether a gamepad with the standard layout is
+// connected.
+func GamepadConnected() bool {
+	for _, id := range gamepadIDs {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			return true
+		}
+	}
+	return false
+}
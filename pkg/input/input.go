@@ -0,0 +1,218 @@
+// Package input maps named actions to rebindable keys. Game code asks for
+// actions instead of keys, so the player can change the bindings.
+package input
+
+import (
+	"jamegam/pkg/i18n"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is something the player can do with a key.
+type Action string
+
+const (
+	ActionMenu         Action = "menu" // pause, or go back in menus
+	ActionConfirm      Action = "confirm"
+	ActionFocusNext    Action = "focus_next"
+	ActionStartWave    Action = "start_wave"
+	ActionFastForward  Action = "fast_forward"
+	ActionToggleRange  Action = "toggle_range"
+	ActionTowerBasic   Action = "tower_basic"
+	ActionTowerTacks   Action = "tower_tacks"
+	ActionTowerIce     Action = "tower_ice"
+	ActionTowerAoe     Action = "tower_aoe"
+	ActionTowerSuper   Action = "tower_super"
+	ActionUpgradeLeft  Action = "upgrade_left" // the first branch of the upgrade tree
+	ActionUpgradeRight Action = "upgrade_right"
+	ActionSell         Action = "sell"
+	ActionCancel       Action = "cancel"
+	ActionHat          Action = "hat"
+	ActionHistory      Action = "history"
+	ActionCursorUp     Action = "cursor_up"
+	ActionCursorDown   Action = "cursor_down"
+	ActionCursorLeft   Action = "cursor_left"
+	ActionCursorRight  Action = "cursor_right"
+)
+
+// Actions lists all actions in the order they are shown to the player.
+var Actions = []Action{
+	ActionMenu,
+	ActionConfirm,
+	ActionFocusNext,
+	ActionStartWave,
+	ActionFastForward,
+	ActionToggleRange,
+	ActionTowerBasic,
+	ActionTowerTacks,
+	ActionTowerIce,
+	ActionTowerAoe,
+	ActionTowerSuper,
+	ActionUpgradeLeft,
+	ActionUpgradeRight,
+	ActionSell,
+	ActionCancel,
+	ActionHat,
+	ActionHistory,
+	ActionCursorUp,
+	ActionCursorDown,
+	ActionCursorLeft,
+	ActionCursorRight,
+}
+
+// String returns the display name of the action in the current language.
+func (a Action) String() string {
+	return i18n.T("action." + string(a))
+}
+
+// SlotsPerAction is the number of keys that can be bound to one action.
+const SlotsPerAction = 2
+
+// Bindings maps every action to its keys.
+type Bindings map[Action][]ebiten.Key
+
+// DefaultBindings returns the bindings used when the player changed nothing.
+func DefaultBindings() Bindings {
+	return Bindings{
+		ActionMenu:         {ebiten.KeyEscape, ebiten.KeyP},
+		ActionConfirm:      {ebiten.KeyEnter},
+		ActionFocusNext:    {ebiten.KeyTab},
+		ActionStartWave:    {ebiten.KeySpace},
+		ActionFastForward:  {ebiten.KeyF},
+		ActionToggleRange:  {ebiten.KeyR},
+		ActionTowerBasic:   {ebiten.Key1},
+		ActionTowerTacks:   {ebiten.Key2},
+		ActionTowerIce:     {ebiten.Key3},
+		ActionTowerAoe:     {ebiten.Key4},
+		ActionTowerSuper:   {ebiten.Key5},
+		ActionUpgradeLeft:  {ebiten.KeyS},
+		ActionUpgradeRight: {ebiten.KeyD},
+		ActionSell:         {ebiten.KeyX},
+		ActionCancel:       {ebiten.KeyQ},
+		ActionHat:          {ebiten.KeyH},
+		ActionHistory:      {ebiten.KeyL},
+		ActionCursorUp:     {ebiten.KeyArrowUp},
+		ActionCursorDown:   {ebiten.KeyArrowDown},
+		ActionCursorLeft:   {ebiten.KeyArrowLeft},
+		ActionCursorRight:  {ebiten.KeyArrowRight},
+	}
+}
+
+// Current holds the bindings in use. It is filled by Load at startup.
+var Current = DefaultBindings()
+
+// Key returns the key in the given slot of the action, and false if the slot
+// is empty.
+func (b Bindings) Key(action Action, slot int) (ebiten.Key, bool) {
+	keys := b[action]
+	if slot < 0 || slot >= len(keys) {
+		return 0, false
+	}
+	return keys[slot], true
+}
+
+// Bind binds the key to the slot of the action. A key can only trigger one
+// action, so it is taken away from the action it was bound to before, which
+// is returned. The result is "" if the key was free.
+func (b Bindings) Bind(action Action, slot int, key ebiten.Key) Action {
+	var previous Action
+	for other, keys := range b {
+		if i := slices.Index(keys, key); i != -1 && other != action {
+			b[other] = slices.Delete(keys, i, i+1)
+			previous = other
+		}
+	}
+	keys := slices.DeleteFunc(b[action], func(k ebiten.Key) bool { return k == key })
+	if slot >= len(keys) {
+		keys = append(keys, key)
+	} else {
+		keys[slot] = key
+	}
+	b[action] = keys
+	return previous
+}
+
+// Unbind clears the slot of the action.
+func (b Bindings) Unbind(action Action, slot int) {
+	if keys := b[action]; slot >= 0 && slot < len(keys) {
+		b[action] = slices.Delete(keys, slot, slot+1)
+	}
+}
+
+// Conflicts returns the keys bound to more than one action, e.g. after the
+// bindings file was edited by hand.
+func (b Bindings) Conflicts() map[ebiten.Key][]Action {
+	byKey := map[ebiten.Key][]Action{}
+	for _, action := range Actions {
+		for _, key := range b[action] {
+			byKey[key] = append(byKey[key], action)
+		}
+	}
+	for key, actions := range byKey {
+		if len(actions) < 2 {
+			delete(byKey, key)
+		}
+	}
+	return byKey
+}
+
+// sanitize drops unknown actions and fills in missing ones with defaults.
+func (b Bindings) sanitize() {
+	defaults := DefaultBindings()
+	for action := range b {
+		if _, ok := defaults[action]; !ok {
+			delete(b, action)
+		}
+	}
+	for action, keys := range defaults {
+		if _, ok := b[action]; !ok {
+			b[action] = keys
+		}
+	}
+	for action, keys := range b {
+		if len(keys) > SlotsPerAction {
+			b[action] = keys[:SlotsPerAction]
+		}
+	}
+}
+
+// JustPressed reports whether a key or gamepad button of the action was
+// pressed this tick.
+func JustPressed(action Action) bool {
+	return held[action] == 1
+}
+
+// Pressed reports whether a key or gamepad button of the action is held down.
+func Pressed(action Action) bool {
+	return held[action] > 0
+}
+
+// Repeated is like JustPressed, but also repeats while the action is held,
+// e.g. for moving a cursor.
+func Repeated(action Action) bool {
+	const delay, interval = 20, 6 // ticks
+	d := held[action]
+	return d == 1 || (d >= delay && (d-delay)%interval == 0)
+}
+
+// KeyNames returns the keys of the action for display, e.g. "Escape / P".
+func KeyNames(action Action) string {
+	names := []string{}
+	for _, key := range Current[action] {
+		names = append(names, KeyName(key))
+	}
+	if len(names) == 0 {
+		return i18n.T("keybindings.none")
+	}
+	return strings.Join(names, " / ")
+}
+
+// KeyName returns a short display name of the key.
+func KeyName(key ebiten.Key) string {
+	name := key.String()
+	name = strings.TrimPrefix(name, "Digit")
+	name = strings.TrimPrefix(name, "Arrow")
+	return name
+}
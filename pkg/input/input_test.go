@@ -0,0 +1,74 @@
+package input
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TestBindings_BindMovesKey verifies that a key only ever triggers one action.
+func TestBindings_BindMovesKey(t *testing.T) {
+	b := DefaultBindings()
+	previous := b.Bind(ActionSell, 0, ebiten.KeyR)
+	if previous != ActionToggleRange {
+		t.Fatalf("expected key to be taken from %v, got %q", ActionToggleRange, previous)
+	}
+	if len(b[ActionToggleRange]) != 0 {
+		t.Fatalf("expected toggle range to be unbound, got %v", b[ActionToggleRange])
+	}
+	if !slices.Equal(b[ActionSell], []ebiten.Key{ebiten.KeyR}) {
+		t.Fatalf("expected sell on R, got %v", b[ActionSell])
+	}
+	if len(b.Conflicts()) != 0 {
+		t.Fatalf("expected no conflicts, got %v", b.Conflicts())
+	}
+}
+
+// TestBindings_Conflicts verifies that hand edited duplicates are reported.
+func TestBindings_Conflicts(t *testing.T) {
+	b := DefaultBindings()
+	b[ActionSell] = []ebiten.Key{ebiten.KeyEscape}
+	conflicts := b.Conflicts()
+	if len(conflicts) != 1 || len(conflicts[ebiten.KeyEscape]) != 2 {
+		t.Fatalf("expected escape to conflict, got %v", conflicts)
+	}
+}
+
+// TestGamepadButtons_NoConflicts verifies that every gamepad button triggers
+// one action only.
+func TestGamepadButtons_NoConflicts(t *testing.T) {
+	if conflicts := GamepadConflicts(); len(conflicts) != 0 {
+		t.Fatalf("expected no gamepad conflicts, got %v", conflicts)
+	}
+	conflicts := buttonConflicts(map[Action][]ebiten.StandardGamepadButton{
+		ActionFocusNext:  {ebiten.StandardGamepadButtonLeftBottom},
+		ActionCursorDown: {ebiten.StandardGamepadButtonLeftBottom},
+	})
+	if len(conflicts[ebiten.StandardGamepadButtonLeftBottom]) != 2 {
+		t.Fatalf("expected d-pad down to conflict, got %v", conflicts)
+	}
+}
+
+// TestBindings_SaveLoad verifies that bindings survive a round trip to disk.
+func TestBindings_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keybindings.json")
+	b := DefaultBindings()
+	b.Bind(ActionStartWave, 1, ebiten.KeyF)
+	delete(b, ActionCancel) // missing actions fall back to the defaults
+	if err := SaveTo(path, b); err != nil {
+		t.Fatalf("expected no error saving, got %v", err)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	if !slices.Equal(loaded[ActionStartWave], []ebiten.Key{ebiten.KeySpace, ebiten.KeyF}) {
+		t.Fatalf("expected start wave on space and F, got %v", loaded[ActionStartWave])
+	}
+	if !slices.Equal(loaded[ActionCancel], DefaultBindings()[ActionCancel]) {
+		t.Fatalf("expected default cancel keys, got %v", loaded[ActionCancel])
+	}
+}
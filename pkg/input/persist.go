@@ -0,0 +1,78 @@
+package input
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// ConfigPath returns the path of the bindings file in the user config
+// directory, next to the settings.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jamegam", "keybindings.json"), nil
+}
+
+// LoadFrom reads bindings from the given file. Missing actions keep their
+// default keys.
+func LoadFrom(path string) (Bindings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultBindings(), err
+	}
+	b := Bindings{}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return DefaultBindings(), err
+	}
+	b.sanitize()
+	return b, nil
+}
+
+// SaveTo writes the bindings to the given file, creating its directory.
+func SaveTo(path string, b Bindings) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load reads the bindings file into Current. If there is none the defaults
+// are used.
+func Load() {
+	path, err := ConfigPath()
+	if err != nil {
+		log.Printf("No config directory, using default keybindings: %v", err)
+		return
+	}
+	b, err := LoadFrom(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Could not load keybindings, using defaults: %v", err)
+	}
+	for key, actions := range b.Conflicts() {
+		log.Printf("Key %v is bound to several actions: %v", key, actions)
+	}
+	for button, actions := range GamepadConflicts() {
+		log.Printf("Gamepad button %v is mapped to several actions: %v", button, actions)
+	}
+	Current = b
+}
+
+// Save writes Current to the bindings file.
+func Save() {
+	path, err := ConfigPath()
+	if err != nil {
+		log.Printf("No config directory, keybindings are not saved: %v", err)
+		return
+	}
+	if err := SaveTo(path, Current); err != nil {
+		log.Printf("Could not save keybindings: %v", err)
+	}
+}
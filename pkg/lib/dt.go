@@ -1,6 +1,10 @@
 package lib
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 func Dt() float64 {
 	tps := ebiten.ActualTPS()
@@ -15,3 +19,25 @@ func Dt() float64 {
 
 	return realdt / 1.0
 }
+
+// TimeScale slows down or speeds up the game simulation, menus and other UI
+// keep running in real time.
+var TimeScale = 1.0
+
+// SimDt is Dt scaled by TimeScale. Everything that belongs to the simulation,
+// like enemies, towers and spawn timers, has to use it.
+func SimDt() float64 {
+	return Dt() * TimeScale
+}
+
+// SubSteps returns how many steps a tick of the simulation is split into.
+// With fast-forward a tick covers more game time, and one step per real time
+// tick keeps enemies and projectiles from skipping past each other.
+func SubSteps() int {
+	return max(1, int(math.Ceil(TimeScale)))
+}
+
+// StepDt is the game time covered by one simulation step, see SubSteps.
+func StepDt() float64 {
+	return SimDt() / float64(SubSteps())
+}
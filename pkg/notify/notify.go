@@ -0,0 +1,274 @@
+// Package notify shows short messages to the player as stacked toasts and
+// keeps a history of them. Messages are queued by priority, so a burst of
+// events does not overwrite the one that matters.
+package notify
+
+import (
+	"fmt"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/palette"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Category tells what a message is about. It picks the color and sound.
+type Category int
+
+const (
+	CategoryInfo Category = iota
+	CategoryEconomy
+	CategoryWave
+	CategoryError
+)
+
+// Priority decides which queued message is shown first. High priority
+// messages push out lower ones when all toast slots are taken.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+const (
+	maxToasts  = 3
+	lifetime   = 3.0 // seconds a toast is shown, including the fade
+	fadeTime   = 0.5
+	staleAfter = 5.0 // seconds a low priority message may wait for a slot
+	maxHistory = 100
+)
+
+// Message is one notification.
+type Message struct {
+	Text     string
+	Category Category
+	Priority Priority
+	Count    int // how often the same text was pushed while it was shown
+}
+
+type toast struct {
+	Message
+	life    float64
+	waiting float64
+}
+
+// Queue holds the shown toasts, the messages waiting for a slot and the
+// history.
+type Queue struct {
+	toasts  []*toast
+	pending []*toast
+	history []Message
+
+	historyOpen   bool
+	historyScroll int
+}
+
+// Default holds the messages of the gameplay scene.
+var Default = New()
+
+func New() *Queue {
+	return &Queue{}
+}
+
+// Push queues a message. If the same text is already shown, that toast is
+// shown longer instead.
+func (q *Queue) Push(category Category, priority Priority, txt string) {
+	q.history = append(q.history, Message{Text: txt, Category: category, Priority: priority, Count: 1})
+	if len(q.history) > maxHistory {
+		q.history = q.history[1:]
+	}
+	for _, t := range q.toasts {
+		if t.Text == txt {
+			t.Count++
+			t.life = 0
+			return
+		}
+	}
+	q.pending = append(q.pending, &toast{Message: Message{Text: txt, Category: category, Priority: priority, Count: 1}})
+	// stable, so messages of the same priority keep their order
+	slices.SortStableFunc(q.pending, func(a, b *toast) int { return int(b.Priority - a.Priority) })
+}
+
+func (q *Queue) Update(dt float64) {
+	q.toasts = slices.DeleteFunc(q.toasts, func(t *toast) bool {
+		t.life += dt
+		return t.life >= lifetime
+	})
+	q.pending = slices.DeleteFunc(q.pending, func(t *toast) bool {
+		t.waiting += dt
+		return t.Priority == PriorityLow && t.waiting >= staleAfter
+	})
+
+	for len(q.pending) > 0 {
+		next := q.pending[0]
+		if len(q.toasts) >= maxToasts {
+			q.fadeOutBelow(next.Priority)
+			break
+		}
+		q.pending = q.pending[1:]
+		q.toasts = append(q.toasts, next)
+		playSound(next.Category)
+	}
+}
+
+// fadeOutBelow starts fading out the oldest toast with a lower priority than
+// the given one, to make room for it. Nothing happens while a toast is
+// already fading out.
+func (q *Queue) fadeOutBelow(priority Priority) {
+	for _, t := range q.toasts {
+		if t.life >= lifetime-fadeTime {
+			return
+		}
+	}
+	for _, t := range q.toasts {
+		if t.Priority < priority {
+			t.life = lifetime - fadeTime
+			return
+		}
+	}
+}
+
+func playSound(category Category) {
+	if audio.Controller == nil {
+		return
+	}
+	switch category {
+	case CategoryError:
+		audio.Controller.PlayUI("error")
+	case CategoryWave:
+		audio.Controller.PlayUI("notification")
+	}
+}
+
+// Shown returns the texts of the toasts on screen, oldest first.
+func (q *Queue) Shown() []string {
+	texts := []string{}
+	for _, t := range q.toasts {
+		texts = append(texts, t.Text)
+	}
+	return texts
+}
+
+// History returns all messages pushed since the last Clear, oldest first.
+func (q *Queue) History() []Message {
+	return q.history
+}
+
+// ToggleHistory opens or closes the history log.
+func (q *Queue) ToggleHistory() {
+	q.historyOpen = !q.historyOpen
+	q.historyScroll = 0
+}
+
+func (q *Queue) HistoryOpen() bool {
+	return q.historyOpen
+}
+
+// ScrollHistory scrolls the history log by the given lines, positive values
+// go back in time.
+func (q *Queue) ScrollHistory(lines int) {
+	q.historyScroll = min(max(0, q.historyScroll+lines), max(0, len(q.history)-historyLines))
+}
+
+// Clear removes all messages and the history.
+func (q *Queue) Clear() {
+	q.toasts = nil
+	q.pending = nil
+	q.history = nil
+	q.historyScroll = 0
+}
+
+// ========================================
+// Drawing
+// ========================================
+
+const (
+	toastGap     = 4
+	historyLines = 14
+)
+
+// lineHeight returns the height of one line of text in the face, which
+// follows the text scale setting.
+func lineHeight(face text.Face) float32 {
+	m := face.Metrics()
+	return float32(m.HAscent + m.HDescent)
+}
+
+// Draw draws the toasts stacked up from the bottom of the given area, newest
+// at the bottom, and the history log if it is open.
+func (q *Queue) Draw(screen *ebiten.Image, face text.Face, left, bottom, width float32) {
+	toastHeight := lineHeight(face) + 10
+	y := bottom
+	for i := len(q.toasts) - 1; i >= 0; i-- {
+		t := q.toasts[i]
+		y -= toastHeight
+		alpha := float32(1)
+		if t.life > lifetime-fadeTime {
+			alpha = float32((lifetime - t.life) / fadeTime)
+		}
+		drawLine(screen, face, t.Message, left, y, width, toastHeight, alpha)
+		y -= toastGap
+	}
+
+	if q.historyOpen {
+		q.drawHistory(screen, face, left, 8, width)
+	}
+}
+
+func (q *Queue) drawHistory(screen *ebiten.Image, face text.Face, left, top, width float32) {
+	line := lineHeight(face) + 2
+	height := historyLines*line + 8
+	vector.DrawFilledRect(screen, left, top, width, height, color.RGBA{0, 0, 0, 200}, false)
+	vector.StrokeRect(screen, left, top, width, height, 2, color.RGBA{100, 255, 100, 255}, false)
+
+	end := len(q.history) - q.historyScroll
+	start := max(0, end-historyLines)
+	for i, m := range q.history[start:end] {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(float64(left+10), float64(top+4+float32(i)*line))
+		op.ColorScale.ScaleWithColor(categoryColor(m.Category))
+		text.Draw(screen, "> "+m.Text, face, op)
+	}
+}
+
+func drawLine(screen *ebiten.Image, face text.Face, m Message, x, y, width, height, alpha float32) {
+	vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{0, 0, 0, uint8(160 * alpha)}, false)
+	accent := categoryColor(m.Category)
+	vector.DrawFilledRect(screen, x, y, 6, height, scaleAlpha(accent, alpha), false)
+
+	txt := "> " + m.Text
+	if m.Count > 1 {
+		txt += fmt.Sprintf(" (x%d)", m.Count)
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x+12), float64(y+5))
+	op.ColorScale.ScaleAlpha(alpha)
+	text.Draw(screen, txt, face, op)
+}
+
+func scaleAlpha(c color.RGBA, alpha float32) color.RGBA {
+	return color.RGBA{
+		uint8(float32(c.R) * alpha),
+		uint8(float32(c.G) * alpha),
+		uint8(float32(c.B) * alpha),
+		uint8(float32(c.A) * alpha),
+	}
+}
+
+func categoryColor(category Category) color.RGBA {
+	switch category {
+	case CategoryEconomy:
+		return color.RGBA{255, 215, 60, 255}
+	case CategoryWave:
+		return color.RGBA{120, 180, 255, 255}
+	case CategoryError:
+		return palette.Bad()
+	default:
+		return color.RGBA{255, 255, 255, 255}
+	}
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestQueue_Stacks verifies that messages no longer overwrite each other.
+func TestQueue_Stacks(t *testing.T) {
+	q := New()
+	q.Push(CategoryEconomy, PriorityNormal, "a")
+	q.Push(CategoryEconomy, PriorityNormal, "b")
+	q.Update(0.1)
+	if !slices.Equal(q.Shown(), []string{"a", "b"}) {
+		t.Fatalf("expected both messages to be shown, got %v", q.Shown())
+	}
+	q.Update(lifetime)
+	if len(q.Shown()) != 0 {
+		t.Fatalf("expected messages to expire, got %v", q.Shown())
+	}
+	if len(q.History()) != 2 {
+		t.Fatalf("expected two messages in the history, got %v", q.History())
+	}
+}
+
+// TestQueue_HighPriorityFirst verifies that high priority messages skip the
+// queue and push out lower ones when all slots are taken.
+func TestQueue_HighPriorityFirst(t *testing.T) {
+	q := New()
+	for _, txt := range []string{"1", "2", "3"} {
+		q.Push(CategoryInfo, PriorityLow, txt)
+	}
+	q.Update(0.1)
+	q.Push(CategoryInfo, PriorityLow, "4")
+	q.Push(CategoryWave, PriorityHigh, "wave")
+	q.Update(0.1) // the oldest low toast starts fading
+	if slices.Contains(q.Shown(), "wave") {
+		t.Fatalf("expected the wave message to wait for a slot, got %v", q.Shown())
+	}
+	q.Update(fadeTime)
+	if !slices.Equal(q.Shown(), []string{"2", "3", "wave"}) {
+		t.Fatalf("expected the wave message to push out the oldest, got %v", q.Shown())
+	}
+}
+
+// TestQueue_Duplicates verifies that repeated messages are counted instead of
+// stacked.
+func TestQueue_Duplicates(t *testing.T) {
+	q := New()
+	q.Push(CategoryError, PriorityHigh, "no")
+	q.Update(0.1)
+	q.Push(CategoryError, PriorityHigh, "no")
+	q.Update(0.1)
+	if len(q.toasts) != 1 || q.toasts[0].Count != 2 {
+		t.Fatalf("expected one toast counted twice, got %v", q.Shown())
+	}
+}
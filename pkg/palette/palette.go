@@ -0,0 +1,64 @@
+// Package palette holds the colors that carry meaning, like a valid or an
+// invalid tower placement. They follow the colorblind setting, so the
+// difference stays visible for everyone.
+package palette
+
+import (
+	"image/color"
+	"jamegam/pkg/settings"
+)
+
+type palette struct {
+	good, warning, bad color.RGBA
+}
+
+// The colorblind palettes are taken from the Okabe-Ito colors, which stay
+// distinct for the common kinds of color blindness.
+var palettes = map[string]palette{
+	"off": {
+		good:    color.RGBA{80, 220, 80, 255},
+		warning: color.RGBA{240, 200, 60, 255},
+		bad:     color.RGBA{230, 70, 60, 255},
+	},
+	"deuteranopia": {
+		good:    color.RGBA{86, 180, 233, 255},
+		warning: color.RGBA{240, 228, 66, 255},
+		bad:     color.RGBA{213, 94, 0, 255},
+	},
+	"protanopia": {
+		good:    color.RGBA{0, 114, 178, 255},
+		warning: color.RGBA{240, 228, 66, 255},
+		bad:     color.RGBA{230, 159, 0, 255},
+	},
+	"tritanopia": {
+		good:    color.RGBA{0, 158, 115, 255},
+		warning: color.RGBA{204, 121, 167, 255},
+		bad:     color.RGBA{213, 94, 0, 255},
+	},
+}
+
+func current() palette {
+	if p, ok := palettes[settings.Current.Colorblind]; ok {
+		return p
+	}
+	return palettes["off"]
+}
+
+// Good is for healthy and valid things.
+func Good() color.RGBA {
+	return current().good
+}
+
+// Warning is between good and bad.
+func Warning() color.RGBA {
+	return current().warning
+}
+
+// Bad is for low health, errors and invalid things.
+func Bad() color.RGBA {
+	return current().bad
+}
+
+// Outline is drawn around indicators in high contrast mode, so they stand
+// out from the map.
+var Outline = color.RGBA{0, 0, 0, 255}
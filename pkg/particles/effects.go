@@ -0,0 +1,78 @@
+package particles
+
+import "image/color"
+
+// Presets for the in-game effects, sizes are in screen pixels.
+var (
+	// Muzzle is emitted along the shot direction of projectile towers.
+	Muzzle = &Emitter{
+		Count: 5, Spread: 40,
+		SpeedMin: 80, SpeedMax: 180,
+		LifeMin: 0.1, LifeMax: 0.25,
+		Drag:      4,
+		SizeStart: 8, SizeEnd: 2,
+		ColorStart: color.RGBA{255, 230, 120, 255},
+		ColorEnd:   color.RGBA{223, 113, 38, 0},
+	}
+
+	// Hit is emitted where a projectile hits an enemy.
+	Hit = &Emitter{
+		Count: 6, Spread: 360,
+		SpeedMin: 60, SpeedMax: 160,
+		LifeMin: 0.15, LifeMax: 0.3,
+		Gravity:   300,
+		SizeStart: 8, SizeEnd: 2,
+		ColorStart: color.RGBA{255, 255, 255, 255},
+		ColorEnd:   color.RGBA{160, 160, 160, 0},
+	}
+
+	// Explosion is emitted by exploding aoe projectiles.
+	Explosion = &Emitter{
+		Count: 32, Spread: 360,
+		SpeedMin: 60, SpeedMax: 320,
+		LifeMin: 0.25, LifeMax: 0.6,
+		Drag:      3,
+		Jitter:    8,
+		SizeStart: 22, SizeEnd: 4,
+		ColorStart: color.RGBA{255, 190, 80, 255},
+		ColorEnd:   color.RGBA{60, 50, 50, 0},
+	}
+
+	// IceBurst is a ring of frost expanding to the range of the ice tower.
+	IceBurst = &Emitter{
+		Count: 28, Spread: 360,
+		SpeedMin: 150, SpeedMax: 190,
+		LifeMin: 0.4, LifeMax: 0.5,
+		Drag:      1,
+		SizeStart: 10, SizeEnd: 3,
+		ColorStart: color.RGBA{200, 240, 255, 230},
+		ColorEnd:   color.RGBA{120, 190, 255, 0},
+		Shape:      ShapeSquare,
+	}
+
+	// CashPulse is emitted when the mana tower collects mana.
+	CashPulse = &Emitter{
+		Count: 12, Spread: 360,
+		SpeedMin: 30, SpeedMax: 90,
+		LifeMin: 0.5, LifeMax: 0.8,
+		Gravity:   -120,
+		Drag:      2,
+		Jitter:    12,
+		SizeStart: 8, SizeEnd: 4,
+		ColorStart: color.RGBA{255, 215, 60, 255},
+		ColorEnd:   color.RGBA{255, 240, 150, 0},
+		Shape:      ShapeSquare,
+	}
+
+	// Death is emitted where an enemy is killed.
+	Death = &Emitter{
+		Count: 12, Spread: 360,
+		SpeedMin: 50, SpeedMax: 150,
+		LifeMin: 0.3, LifeMax: 0.6,
+		Gravity:   200,
+		Drag:      2,
+		SizeStart: 10, SizeEnd: 2,
+		ColorStart: color.RGBA{200, 200, 210, 255},
+		ColorEnd:   color.RGBA{90, 90, 100, 0},
+	}
+)
@@ -0,0 +1,164 @@
+// Package particles spawns short lived particles for visual effects. The
+// particles are purely cosmetic and never affect the game state.
+package particles
+
+import (
+	"image/color"
+	"jamegam/pkg/lib"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Shape int
+
+const (
+	ShapeCircle Shape = iota
+	ShapeSquare
+)
+
+// Emitter describes a burst of particles, see the presets in effects.go.
+type Emitter struct {
+	Count int
+	// Spread is the cone in degrees around the emit direction the particles
+	// fly into, 360 emits in all directions.
+	Spread   float32
+	SpeedMin float32
+	SpeedMax float32
+	LifeMin  float64
+	LifeMax  float64
+	// Gravity pulls particles down in pixels per second², negative values
+	// let them rise.
+	Gravity float32
+	// Drag is the fraction of velocity lost per second.
+	Drag float32
+	// Jitter randomly offsets the start position up to this many pixels.
+	Jitter float32
+
+	SizeStart  float32
+	SizeEnd    float32
+	ColorStart color.RGBA
+	ColorEnd   color.RGBA
+	Shape      Shape
+	// Sprite is drawn instead of the shape if set, scaled to the size.
+	Sprite *ebiten.Image
+}
+
+type particle struct {
+	position lib.Vec2
+	velocity lib.Vec2
+	life     float64
+	maxLife  float64
+	emitter  *Emitter
+}
+
+// System updates and draws all live particles.
+type System struct {
+	particles *lib.FreeList[particle]
+	count     int
+	max       int
+}
+
+// Default is the system used for all in-game effects.
+var Default = NewSystem(1024)
+
+// NewSystem creates a system that holds at most max particles, further
+// particles are dropped.
+func NewSystem(max int) *System {
+	return &System{
+		particles: lib.NewFreeList[particle](max),
+		max:       max,
+	}
+}
+
+// Burst emits particles in all directions the emitter allows, starting to
+// the right.
+func (s *System) Burst(em *Emitter, at lib.Vec2) {
+	s.Emit(em, at, lib.NewVec2(1, 0))
+}
+
+// Emit emits particles around the given direction.
+func (s *System) Emit(em *Emitter, at, direction lib.Vec2) {
+	direction = direction.Normalize()
+	if direction.Len() == 0 {
+		direction = lib.NewVec2(1, 0)
+	}
+	for i := 0; i < em.Count && s.count < s.max; i++ {
+		angle := (rand.Float32() - 0.5) * em.Spread
+		speed := em.SpeedMin + rand.Float32()*(em.SpeedMax-em.SpeedMin)
+		jitter := lib.NewVec2(rand.Float32()-0.5, rand.Float32()-0.5).Mul(2 * em.Jitter)
+		s.particles.Insert(particle{
+			position: at.Add(jitter),
+			velocity: direction.Rotate(angle).Mul(speed),
+			maxLife:  em.LifeMin + rand.Float64()*(em.LifeMax-em.LifeMin),
+			emitter:  em,
+		})
+		s.count++
+	}
+}
+
+// Update moves particles and removes the expired ones.
+func (s *System) Update(dt float64) {
+	s.particles.FuncAll(func(idx int, p particle) {
+		p.life += dt
+		if p.life >= p.maxLife {
+			s.particles.Remove(idx)
+			s.count--
+			return
+		}
+		fdt := float32(dt)
+		p.velocity = p.velocity.Mul(max(0, 1-p.emitter.Drag*fdt))
+		p.velocity.Y += p.emitter.Gravity * fdt
+		p.position = p.position.Add(p.velocity.Mul(fdt))
+		s.particles.Set(idx, p)
+	})
+}
+
+func (s *System) Draw(screen *ebiten.Image) {
+	s.particles.FuncAll(func(_ int, p particle) {
+		em := p.emitter
+		t := float32(p.life / p.maxLife)
+		size := em.SizeStart + (em.SizeEnd-em.SizeStart)*t
+		clr := lerpColor(em.ColorStart, em.ColorEnd, t)
+
+		if em.Sprite != nil {
+			w := float64(em.Sprite.Bounds().Dx())
+			geom := ebiten.GeoM{}
+			geom.Translate(-w/2, -float64(em.Sprite.Bounds().Dy())/2)
+			geom.Scale(float64(size)/w, float64(size)/w)
+			geom.Translate(float64(p.position.X), float64(p.position.Y))
+			op := &ebiten.DrawImageOptions{GeoM: geom}
+			op.ColorScale.ScaleWithColor(clr)
+			screen.DrawImage(em.Sprite, op)
+			return
+		}
+
+		switch em.Shape {
+		case ShapeSquare:
+			vector.DrawFilledRect(screen, p.position.X-size/2, p.position.Y-size/2, size, size, clr, false)
+		default:
+			vector.DrawFilledCircle(screen, p.position.X, p.position.Y, size/2, clr, false)
+		}
+	})
+}
+
+// Clear removes all particles.
+func (s *System) Clear() {
+	s.particles.Clear()
+	s.count = 0
+}
+
+// lerpColor blends two colors, the result is premultiplied as ebiten expects.
+func lerpColor(a, b color.RGBA, t float32) color.RGBA {
+	lerp := func(x, y uint8) float32 {
+		return float32(x) + (float32(y)-float32(x))*t
+	}
+	alpha := lerp(a.A, b.A) / 255
+	return color.RGBA{
+		R: uint8(lerp(a.R, b.R) * alpha),
+		G: uint8(lerp(a.G, b.G) * alpha),
+		B: uint8(lerp(a.B, b.B) * alpha),
+		A: uint8(alpha * 255),
+	}
+}
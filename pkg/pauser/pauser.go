@@ -1,4 +0,0 @@
-package pauser
-
-var IsPaused bool
-var IsHalted bool
@@ -0,0 +1,134 @@
+package scene
+
+import (
+	"image/color"
+	"jamegam/pkg/lib"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type fadePhase int
+
+const (
+	fadeNone fadePhase = iota
+	fadeOut
+	fadeIn
+)
+
+// Manager holds a stack of scenes and handles transitions between them.
+type Manager struct {
+	stack []Scene
+
+	fadePhase    fadePhase
+	fadeTimer    float64
+	fadeDuration float64
+	fadeAction   func()
+}
+
+// NewManager creates a new scene manager with the given initial scene.
+func NewManager(initial Scene) *Manager {
+	m := &Manager{}
+	m.Push(initial)
+	return m
+}
+
+// Top returns the topmost scene or nil if the stack is empty.
+func (m *Manager) Top() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Push adds a scene on top of the stack, pausing the scene below.
+func (m *Manager) Push(s Scene) {
+	if p, ok := m.Top().(Pausable); ok {
+		p.SetPaused(true)
+	}
+	m.stack = append(m.stack, s)
+	s.Enter(m)
+}
+
+// Pop removes the topmost scene, resuming the scene below.
+func (m *Manager) Pop() {
+	top := m.Top()
+	if top == nil {
+		return
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	top.Exit(m)
+	if p, ok := m.Top().(Pausable); ok {
+		p.SetPaused(false)
+	}
+}
+
+// Replace removes all scenes and pushes the given one.
+func (m *Manager) Replace(s Scene) {
+	for len(m.stack) > 0 {
+		top := m.Top()
+		m.stack = m.stack[:len(m.stack)-1]
+		top.Exit(m)
+	}
+	m.Push(s)
+}
+
+// FadeTo fades to black, runs the given transition, e.g. a Replace, and fades
+// back in. Input is blocked while fading.
+func (m *Manager) FadeTo(duration float64, transition func()) {
+	if m.fadePhase != fadeNone {
+		return
+	}
+	m.fadePhase = fadeOut
+	m.fadeTimer = 0
+	m.fadeDuration = duration
+	m.fadeAction = transition
+}
+
+// IsFading reports whether a fade transition is in progress.
+func (m *Manager) IsFading() bool {
+	return m.fadePhase != fadeNone
+}
+
+// Update updates the topmost scene and advances fades.
+func (m *Manager) Update() error {
+	if m.fadePhase != fadeNone {
+		m.fadeTimer += lib.Dt()
+		if m.fadeTimer >= m.fadeDuration {
+			m.fadeTimer = 0
+			if m.fadePhase == fadeOut {
+				m.fadePhase = fadeIn
+				m.fadeAction()
+				m.fadeAction = nil
+			} else {
+				m.fadePhase = fadeNone
+			}
+		}
+		return nil
+	}
+
+	if top := m.Top(); top != nil {
+		return top.Update(m)
+	}
+	return nil
+}
+
+// Draw draws the visible scenes from bottom to top, followed by the fade.
+func (m *Manager) Draw(screen *ebiten.Image) {
+	first := len(m.stack) - 1
+	for first > 0 && m.stack[first].IsOverlay() {
+		first--
+	}
+	for i := max(first, 0); i < len(m.stack); i++ {
+		m.stack[i].Draw(screen)
+	}
+
+	if m.fadePhase != fadeNone && m.fadeDuration > 0 {
+		alpha := min(1, m.fadeTimer/m.fadeDuration)
+		if m.fadePhase == fadeIn {
+			alpha = 1 - alpha
+		}
+		bounds := screen.Bounds()
+		vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), color.RGBA{0, 0, 0, uint8(alpha * 255)}, false)
+	}
+}
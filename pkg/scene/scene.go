@@ -0,0 +1,22 @@
+package scene
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Scene is a self-contained part of the game, like the main menu or the
+// gameplay itself. Only the topmost scene of the stack is updated.
+type Scene interface {
+	// Enter is called when the scene is added to the stack.
+	Enter(m *Manager)
+	// Exit is called when the scene is removed from the stack.
+	Exit(m *Manager)
+	Update(m *Manager) error
+	Draw(screen *ebiten.Image)
+	// IsOverlay reports whether the scenes below should still be drawn.
+	IsOverlay() bool
+}
+
+// Pausable is implemented by scenes that need to know when another scene is
+// covering them, e.g. to stop animations while the pause menu is open.
+type Pausable interface {
+	SetPaused(paused bool)
+}
@@ -0,0 +1,161 @@
+package settings
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// WindowScales are the window scale factors the player can choose from.
+var WindowScales = []float64{0.5, 0.75, 1.0, 1.25, 1.5}
+
+// ColorblindModes are the color palettes the player can choose from, "off"
+// is the normal one.
+var ColorblindModes = []string{"off", "deuteranopia", "protanopia", "tritanopia"}
+
+// TextScales are the factors the player can enlarge tooltips and messages by.
+var TextScales = []float64{1.0, 1.25, 1.5}
+
+// MinGameSpeed is the slowest the player can make the game.
+const MinGameSpeed = 0.5
+
+// Settings holds all player options that are persisted between runs.
+type Settings struct {
+	MasterVolume float64 `json:"master_volume"`
+	MusicVolume  float64 `json:"music_volume"`
+	SfxVolume    float64 `json:"sfx_volume"`
+	UiVolume     float64 `json:"ui_volume"`
+
+	Fullscreen     bool    `json:"fullscreen"`
+	WindowScale    float64 `json:"window_scale"`
+	IntegerScaling bool    `json:"integer_scaling"` // only scale by whole factors for crisp pixels
+
+	RangeIndicator bool `json:"range_indicator"`
+	Screenshake    bool `json:"screenshake"`
+	HealthBars     bool `json:"health_bars"`
+
+	Language string `json:"language"` // code of the message catalog
+
+	Colorblind    string  `json:"colorblind"` // one of ColorblindModes
+	HighContrast  bool    `json:"high_contrast"`
+	TextScale     float64 `json:"text_scale"`
+	ReducedMotion bool    `json:"reduced_motion"` // no wandering enemies and no screenshake
+	GameSpeed     float64 `json:"game_speed"`     // slows down the game, between MinGameSpeed and 1
+}
+
+// Current holds the settings in use. It is filled by Load at startup.
+var Current = Default()
+
+// Default returns the settings used when no config file exists.
+func Default() Settings {
+	return Settings{
+		MasterVolume:   1.0,
+		MusicVolume:    1.0,
+		SfxVolume:      1.0,
+		UiVolume:       1.0,
+		Fullscreen:     false,
+		WindowScale:    1.0,
+		RangeIndicator: true,
+		Screenshake:    true,
+		HealthBars:     true,
+		Language:       "en",
+		Colorblind:     "off",
+		TextScale:      1.0,
+		GameSpeed:      1.0,
+	}
+}
+
+// sanitize clamps values that might have been edited by hand into their
+// valid ranges.
+func (s *Settings) sanitize() {
+	s.MasterVolume = min(1, max(0, s.MasterVolume))
+	s.MusicVolume = min(1, max(0, s.MusicVolume))
+	s.SfxVolume = min(1, max(0, s.SfxVolume))
+	s.UiVolume = min(1, max(0, s.UiVolume))
+
+	validScale := false
+	for _, scale := range WindowScales {
+		if scale == s.WindowScale {
+			validScale = true
+		}
+	}
+	if !validScale {
+		s.WindowScale = 1.0
+	}
+	if s.Language == "" {
+		s.Language = "en"
+	}
+	if !slices.Contains(ColorblindModes, s.Colorblind) {
+		s.Colorblind = "off"
+	}
+	if !slices.Contains(TextScales, s.TextScale) {
+		s.TextScale = 1.0
+	}
+	s.GameSpeed = min(1, max(MinGameSpeed, s.GameSpeed))
+}
+
+// ConfigPath returns the path of the settings file in the user config
+// directory.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jamegam", "settings.json"), nil
+}
+
+// LoadFrom reads settings from the given file. Missing fields keep their
+// default values.
+func LoadFrom(path string) (Settings, error) {
+	s := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Default(), err
+	}
+	s.sanitize()
+	return s, nil
+}
+
+// SaveTo writes the settings to the given file, creating its directory.
+func SaveTo(path string, s Settings) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load reads the settings file into Current. If there is no settings file
+// (or no config directory, e.g. on the web) the defaults are used.
+func Load() {
+	path, err := ConfigPath()
+	if err != nil {
+		log.Printf("No config directory, using default settings: %v", err)
+		return
+	}
+	s, err := LoadFrom(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Could not load settings, using defaults: %v", err)
+	}
+	Current = s
+}
+
+// Save writes Current to the settings file.
+func Save() {
+	path, err := ConfigPath()
+	if err != nil {
+		log.Printf("No config directory, settings are not saved: %v", err)
+		return
+	}
+	if err := SaveTo(path, Current); err != nil {
+		log.Printf("Could not save settings: %v", err)
+	}
+}
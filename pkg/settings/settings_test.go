@@ -0,0 +1,73 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSettings_SaveLoad verifies that settings survive a round trip to disk.
+func TestSettings_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "settings.json")
+
+	s := Default()
+	s.MusicVolume = 0.25
+	s.Fullscreen = true
+	s.WindowScale = 0.75
+	s.Screenshake = false
+	if err := SaveTo(path, s); err != nil {
+		t.Fatalf("expected no error saving, got %v", err)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	if loaded != s {
+		t.Fatalf("expected %+v, got %+v", s, loaded)
+	}
+}
+
+// TestSettings_LoadMissing verifies that a missing file yields the defaults.
+func TestSettings_LoadMissing(t *testing.T) {
+	loaded, err := LoadFrom(filepath.Join(t.TempDir(), "missing.json"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected not exist error, got %v", err)
+	}
+	if loaded != Default() {
+		t.Fatalf("expected defaults, got %+v", loaded)
+	}
+}
+
+// TestSettings_LoadSanitizes verifies that out of range values are fixed and
+// missing fields keep their defaults.
+func TestSettings_LoadSanitizes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	data := []byte(`{"master_volume": 3, "sfx_volume": -1, "window_scale": 7, "colorblind": "purple", "game_speed": 0.1}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	if loaded.MasterVolume != 1 {
+		t.Fatalf("expected master volume 1, got %f", loaded.MasterVolume)
+	}
+	if loaded.SfxVolume != 0 {
+		t.Fatalf("expected sfx volume 0, got %f", loaded.SfxVolume)
+	}
+	if loaded.WindowScale != 1 {
+		t.Fatalf("expected window scale 1, got %f", loaded.WindowScale)
+	}
+	if loaded.Colorblind != "off" {
+		t.Fatalf("expected colorblind mode off, got %q", loaded.Colorblind)
+	}
+	if loaded.GameSpeed != MinGameSpeed {
+		t.Fatalf("expected game speed %f, got %f", MinGameSpeed, loaded.GameSpeed)
+	}
+	if !loaded.RangeIndicator {
+		t.Fatalf("expected range indicator to keep its default")
+	}
+}
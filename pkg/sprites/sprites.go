@@ -1,40 +1,29 @@
 package sprites
 
 import (
+	"jamegam/pkg/assets"
 	"jamegam/pkg/lib"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 var (
 	SpriteMap     *ebiten.Image
 	SpriteOverMap *ebiten.Image
 
-	SpritePauseMenu      *ebiten.Image
-	SpriteMainMenu       *ebiten.Image
-	SpriteMainMenuButton *ebiten.Image
-	SpriteTutorial       *ebiten.Image
+	SpritePauseMenu *ebiten.Image
+	SpriteMainMenu  *ebiten.Image
+	SpriteTutorial  *ebiten.Image
 )
 
 func init() {
-	var err error
+	lib.Must(assets.LoadImage(&SpriteMap, "map.png"))
 
-	SpriteMap, _, err = ebitenutil.NewImageFromFile("map.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&SpriteOverMap, "over_map.png"))
 
-	SpriteOverMap, _, err = ebitenutil.NewImageFromFile("over_map.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&SpritePauseMenu, "pausemenu.png"))
 
-	SpritePauseMenu, _, err = ebitenutil.NewImageFromFile("pausemenu.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&SpriteMainMenu, "mainmenu.png"))
 
-	SpriteMainMenu, _, err = ebitenutil.NewImageFromFile("mainmenu.png")
-	lib.Must(err)
-
-	SpriteMainMenuButton, _, err = ebitenutil.NewImageFromFile("mainmenu_button.png")
-	lib.Must(err)
-
-	SpriteTutorial, _, err = ebitenutil.NewImageFromFile("tutorial.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&SpriteTutorial, "tutorial.png"))
 }
@@ -0,0 +1,75 @@
+package towers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/lib"
+)
+
+// infoFile defines the base stats of all tower types, keyed by TowerType.key.
+const infoFile = "towers.json"
+
+// TowerInfo holds the base stats of a tower type, shown in the shop.
+type TowerInfo struct {
+	Price    int64   `json:"price"`
+	FireRate float64 `json:"fire_rate"` // seconds between two shots
+	Radius   float32 `json:"radius"`
+	Damage   int     `json:"damage"` // per hit, 0 for towers that don't deal damage
+}
+
+var towerInfos = map[TowerType]TowerInfo{}
+
+func init() {
+	lib.Must(loadInfos())
+	assets.Watch(infoFile, loadInfos)
+}
+
+// loadInfos replaces the stats. Placed towers read them every time, so they
+// change right away.
+func loadInfos() error {
+	reader, err := assets.Open(infoFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	parsed, err := parseInfos(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", infoFile, err)
+	}
+	towerInfos = parsed
+	return nil
+}
+
+// parseInfos reads a tower stats file, which has to define every tower type.
+func parseInfos(r io.Reader) (map[TowerType]TowerInfo, error) {
+	def := map[string]TowerInfo{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, err
+	}
+	parsed := make(map[TowerType]TowerInfo)
+	for towerKey, info := range def {
+		towerType, ok := towerTypeByKey(towerKey)
+		if !ok {
+			return nil, fmt.Errorf("unknown tower %q", towerKey)
+		}
+		if info.Price <= 0 || info.FireRate <= 0 || info.Radius <= 0 {
+			return nil, fmt.Errorf("tower %s needs a price, fire rate and radius", towerKey)
+		}
+		parsed[towerType] = info
+	}
+	for t := TowerTypeBasic; t <= TowerTypeSuper; t++ {
+		if _, ok := parsed[t]; !ok {
+			return nil, fmt.Errorf("tower %s is missing", t.key())
+		}
+	}
+	return parsed, nil
+}
+
+// Info returns the base stats of the tower type.
+func Info(t TowerType) TowerInfo {
+	return towerInfos[t]
+}
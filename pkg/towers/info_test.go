@@ -0,0 +1,18 @@
+package towers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInfosRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"basic": {"price": 100, "fire_rate": 1, "radius": 128, "damage": 1}}`,
+		`{"nope": {"price": 100, "fire_rate": 1, "radius": 128}}`,
+		`{"basic": {"price": 100, "firerate": 1, "radius": 128}}`,
+	} {
+		if _, err := parseInfos(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
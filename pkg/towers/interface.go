@@ -2,6 +2,7 @@ package towers
 
 import (
 	"jamegam/pkg/enemy"
+	"jamegam/pkg/i18n"
 	"jamegam/pkg/lib"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -10,13 +11,19 @@ import (
 type Tower interface {
 	Update(EnemyManager, ProjectileManager) error
 	Draw(screen *ebiten.Image)
+	Type() TowerType
 	Price() int64
 	Radius() float32
+	FireRate() float64
+	Damage() int
+	Kills() int64
+	DamageDealt() int64
 	GetTotalUpgrades() int32
-	GetSpeedUpgrades() int32
-	GetDamageUpgrades() int32
-	SpeedUpgrade()
-	DamageUpgrade()
+	BranchLevel(branch int) int
+	BranchLocked(branch int) bool
+	NextUpgrade(branch int) (Upgrade, bool)
+	Upgrade(branch int)
+	UpgradeValue() int64
 
 	SetSpeedBuff(float32, float32)
 	SetDamageBuff(float32, float32)
@@ -25,6 +32,7 @@ type Tower interface {
 type EnemyManager interface {
 	GetEnemies(point lib.Vec2, radius float32) ([]*enemy.Enemy, []lib.Vec2I)
 	AddMana(int64)
+	RegisterKill(source TowerType)
 }
 
 type ProjectileManager interface {
@@ -43,3 +51,32 @@ const (
 	TowerTypeCash
 	TowerTypeSuper
 )
+
+// String returns the display name of the tower type.
+func (t TowerType) String() string {
+	return i18n.T("tower." + t.key())
+}
+
+// Description returns what the tower does in the current language.
+func (t TowerType) Description() string {
+	return i18n.T("tower_desc." + t.key())
+}
+
+// key names the tower type in message keys.
+func (t TowerType) key() string {
+	switch t {
+	case TowerTypeBasic:
+		return "basic"
+	case TowerTypeTacks:
+		return "tacks"
+	case TowerTypeIce:
+		return "ice"
+	case TowerTypeAoe:
+		return "aoe"
+	case TowerTypeCash:
+		return "cash"
+	case TowerTypeSuper:
+		return "super"
+	}
+	return "none"
+}
@@ -3,8 +3,12 @@ package towers
 import (
 	"image/color"
 	"jamegam/pkg/audio"
+	"jamegam/pkg/camera"
+	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 	"log"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -30,6 +34,12 @@ type ProjectileBasic struct {
 	lifetime    float32
 	maxLifetime float32
 	damage      int
+	Source      TowerType  // the tower type that fired this projectile
+	Owner       *Towercore // the tower that fired this projectile, if any
+	Pierce      int        // enemies the projectile passes through
+	Slow        float32    // seconds hit enemies are slowed down
+
+	hit []*enemy.Enemy // enemies already hit while piercing
 }
 
 func NewProjectileBasic(direction, position lib.Vec2, speed float32, radius float32, maxLifetime float32, damage int) *ProjectileBasic {
@@ -45,7 +55,7 @@ func NewProjectileBasic(direction, position lib.Vec2, speed float32, radius floa
 }
 
 func (p *ProjectileBasic) Update(em EnemyManager, pm ProjectileManager) {
-	dt := float32(lib.Dt())
+	dt := float32(lib.StepDt())
 	offset := p.direction.Mul(p.speed * dt)
 
 	// This might cause discrepancies in the future, but I hope that they're
@@ -60,13 +70,23 @@ func (p *ProjectileBasic) Update(em EnemyManager, pm ProjectileManager) {
 	// Check for collision with enemies
 	enemies, _ := em.GetEnemies(p.position, p.radius)
 	for _, e := range enemies {
+		if slices.Contains(p.hit, e) {
+			continue
+		}
 		newHealth := e.GetHealth() - p.damage
+		p.Owner.recordHit(min(p.damage, e.GetHealth()), newHealth <= 0)
 		e.SetHealth(newHealth)
-		pm.RemoveProjectile(p.SelfIdx)
+		if e.IsDead {
+			em.RegisterKill(p.Source)
+		}
+		slowDown(e, 0.5, p.Slow)
+		particles.Default.Burst(particles.Hit, p.position)
 		log.Println("Hit enemy")
-		return
-		// if newHealth <= 0 {
-		// }
+		if len(p.hit) >= p.Pierce {
+			pm.RemoveProjectile(p.SelfIdx)
+			return
+		}
+		p.hit = append(p.hit, e)
 	}
 
 }
@@ -100,6 +120,9 @@ type ProjectileExplosive struct {
 	damage          int
 	exploding       bool
 	explodingTimer  float32
+	Source          TowerType  // the tower type that fired this projectile
+	Owner           *Towercore // the tower that fired this projectile, if any
+	Slow            float32    // seconds exploded enemies are slowed down
 }
 
 func NewProjectileExplosive(direction, position lib.Vec2, speed float32, radius float32, maxLifetime float32, explosionRadius float32, damage int) *ProjectileExplosive {
@@ -118,14 +141,14 @@ func NewProjectileExplosive(direction, position lib.Vec2, speed float32, radius
 func (p *ProjectileExplosive) Update(em EnemyManager, pm ProjectileManager) {
 
 	if p.exploding {
-		p.explodingTimer += float32(lib.Dt())
+		p.explodingTimer += float32(lib.StepDt())
 		if p.explodingTimer > 0.3 {
 			pm.RemoveProjectile(p.SelfIdx)
 		}
 		return
 	}
 
-	dt := float32(lib.Dt())
+	dt := float32(lib.StepDt())
 	offset := p.direction.Mul(p.speed * dt)
 
 	// This might cause discrepancies in the future, but I hope that they're
@@ -146,10 +169,17 @@ func (p *ProjectileExplosive) Update(em EnemyManager, pm ProjectileManager) {
 	explodedEnemies, _ := em.GetEnemies(p.position, p.explosionRadius)
 	for _, e := range explodedEnemies {
 		newHealth := e.GetHealth() - p.damage
+		p.Owner.recordHit(min(p.damage, e.GetHealth()), newHealth <= 0)
 		e.SetHealth(newHealth)
+		if e.IsDead {
+			em.RegisterKill(p.Source)
+		}
+		slowDown(e, 0.5, p.Slow)
 	}
 	p.exploding = true
-	audio.Controller.Play("aoe_tower_explosion", 0.05)
+	particles.Default.Burst(particles.Explosion, p.position)
+	camera.Main.AddTrauma(0.2)
+	audio.Controller.Play("aoe_tower_explosion")
 
 }
 
@@ -158,8 +188,7 @@ func (p *ProjectileExplosive) Draw(screen *ebiten.Image) {
 	geom.Translate(float64(p.position.X), float64(p.position.Y))
 
 	if p.exploding {
-		vector.DrawFilledCircle(screen, float32(p.position.X), float32(p.position.Y), p.explosionRadius, color.RGBA{223, 113, 38, 150}, false)
-		return
+		return // the explosion is drawn by the particles
 	}
 	vector.DrawFilledCircle(screen, float32(p.position.X), float32(p.position.Y), 10, color.RGBA{50, 50, 50, 255}, false)
 	// TODO: draw shbounds box
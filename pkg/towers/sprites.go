@@ -1,10 +1,10 @@
 package towers
 
 import (
+	"jamegam/pkg/assets"
 	"jamegam/pkg/lib"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 var (
@@ -14,52 +14,19 @@ var (
 	spriteTowerAoe   *ebiten.Image
 	spriteTowerCash  *ebiten.Image
 
-	SpritesheetTowerBasic *ebiten.Image
-	SpritesheetTowerTacks *ebiten.Image
-	SpritesheetTowerIce   *ebiten.Image
-	SpritesheetTowerAoe   *ebiten.Image
-	SpritesheetTowerCash  *ebiten.Image
-	SpritesheetTowerSuper *ebiten.Image
-
 	SpriteProjectileBasic *ebiten.Image
 )
 
 func init() {
-	var err error
-
-	spriteTowerBasic, _, err = ebitenutil.NewImageFromFile("test_tower.png")
-	lib.Must(err)
-
-	spriteTowerTacks, _, err = ebitenutil.NewImageFromFile("test_towertacks.png")
-	lib.Must(err)
-
-	spriteTowerIce, _, err = ebitenutil.NewImageFromFile("test_towerice.png")
-	lib.Must(err)
-
-	spriteTowerAoe, _, err = ebitenutil.NewImageFromFile("test_toweraoe.png")
-	lib.Must(err)
-
-	spriteTowerCash, _, err = ebitenutil.NewImageFromFile("test_towercash.png")
-	lib.Must(err)
-
-	SpritesheetTowerBasic, _, err = ebitenutil.NewImageFromFile("sheet_4_towerbasic.png")
-	lib.Must(err)
-
-	SpritesheetTowerTacks, _, err = ebitenutil.NewImageFromFile("sheet_4_towertacks.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&spriteTowerBasic, "test_tower.png"))
 
-	SpritesheetTowerIce, _, err = ebitenutil.NewImageFromFile("sheet_4_towerice.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&spriteTowerTacks, "test_towertacks.png"))
 
-	SpritesheetTowerAoe, _, err = ebitenutil.NewImageFromFile("sheet_5_toweraoe.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&spriteTowerIce, "test_towerice.png"))
 
-	SpritesheetTowerCash, _, err = ebitenutil.NewImageFromFile("sheet_8_towercash.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&spriteTowerAoe, "test_toweraoe.png"))
 
-	SpritesheetTowerSuper, _, err = ebitenutil.NewImageFromFile("sheet_4_towersuper.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&spriteTowerCash, "test_towercash.png"))
 
-	SpriteProjectileBasic, _, err = ebitenutil.NewImageFromFile("projectile_basic.png")
-	lib.Must(err)
+	lib.Must(assets.LoadImage(&SpriteProjectileBasic, "projectile_basic.png"))
 }
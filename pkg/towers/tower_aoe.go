@@ -1,9 +1,11 @@
 package towers
 
 import (
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerAoe{}
@@ -13,21 +15,16 @@ type TowerAoe struct {
 }
 
 func NewTowerAoe(position lib.Vec2I) *TowerAoe {
-	tc := NewTowercore(3.0, 195.0, SpritesheetTowerAoe, position)
+	tc := NewTowercore(TowerTypeAoe, animation.Default.MustClip("tower_aoe"), position)
 	tc.animSpeed = 0.20
-	tc.spriteFrames = 5
 	return &TowerAoe{
 		Towercore: tc,
 	}
 }
 
-func (t *TowerAoe) Price() int64 {
-	return 250
-}
-
 // Update implements Tower.
 func (t *TowerAoe) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	var furthestProgress float64 = -1
 	var furthestEnemy *enemy.Enemy
 	for _, e := range enemies {
@@ -49,24 +46,29 @@ func (t *TowerAoe) Update(em EnemyManager, pm ProjectileManager) error {
 	}
 
 	// TODO: if there is an ememy in range...
-	if t.ShouldFire(lib.Dt()) && furthestEnemy != nil {
+	if t.ShouldFire(lib.StepDt()) && furthestEnemy != nil {
 		lastIdx, nextIdx := furthestEnemy.GetPathNodes()
 		last := path[lastIdx].ToVec2().Mul(64)
 		next := path[nextIdx].ToVec2().Mul(64)
 		pos := last.Lerp(next, float32(furthestEnemy.GetPathProgress()))
 		dirToEnemy = pos.Sub(t.position.ToVec2()).Normalize()
+		effects := t.effects()
 		prj := NewProjectileExplosive(
 			dirToEnemy,
 			t.position.ToVec2().Add(lib.NewVec2(32, 32)),
 			550.0,
 			12.0,
 			0.45,
-			50,
-			int(1*t.damageUpgrades+1),
+			50+effects.Blast,
+			t.Damage(),
 		)
+		prj.Source = TowerTypeAoe
+		prj.Owner = t.Towercore
+		prj.Slow = effects.Slow
 		idx := pm.AddProjectile(prj)
 		prj.SelfIdx = idx
-		audio.Controller.Play("aoe_tower_shoot", 0)
+		particles.Default.Emit(particles.Muzzle, prj.position.Add(dirToEnemy.Mul(28)), dirToEnemy)
+		audio.Controller.Play("aoe_tower_shoot")
 		t.shotThisTick = true
 	}
 
@@ -0,0 +1,63 @@
+package towers
+
+import (
+	"jamegam/pkg/audio"
+	"jamegam/pkg/enemy"
+	"jamegam/pkg/lib"
+	"testing"
+)
+
+// testField is an EnemyManager and ProjectileManager with every enemy in
+// range of everything.
+type testField struct {
+	enemies     []*enemy.Enemy
+	path        []lib.Vec2I
+	projectiles []Projectile
+}
+
+func (f *testField) GetEnemies(point lib.Vec2, radius float32) ([]*enemy.Enemy, []lib.Vec2I) {
+	return f.enemies, f.path
+}
+
+func (f *testField) AddMana(int64) {}
+
+func (f *testField) RegisterKill(source TowerType) {}
+
+func (f *testField) AddProjectile(projectile Projectile) int {
+	f.projectiles = append(f.projectiles, projectile)
+	return len(f.projectiles) - 1
+}
+
+func (f *testField) RemoveProjectile(idx int) {
+	f.projectiles[idx] = nil
+}
+
+func TestAoeExplosionSounds(t *testing.T) {
+	previous := audio.Controller
+	t.Cleanup(func() { audio.Controller = previous })
+	silent := audio.NewSilentController()
+	audio.Controller = silent
+
+	field := &testField{
+		enemies: []*enemy.Enemy{enemy.NewEnemy(enemy.EnemyTypeBrute, 0, 1, 0.5)},
+		path:    []lib.Vec2I{lib.NewVec2I(0, 0), lib.NewVec2I(1, 0)},
+	}
+	for _, tower := range []*TowerAoe{NewTowerAoe(lib.NewVec2I(64, 64)), NewTowerAoe(lib.NewVec2I(128, 64))} {
+		if err := tower.Update(field, field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(field.projectiles) != 2 {
+		t.Fatalf("%d bombs fired, want 2", len(field.projectiles))
+	}
+	for _, p := range field.projectiles {
+		p.Update(field, field)
+	}
+
+	if got := silent.PlayCount("aoe_tower_shoot"); got != 2 {
+		t.Errorf("aoe_tower_shoot played %d times, want 2", got)
+	}
+	if got := silent.PlayCount("aoe_tower_explosion"); got != 2 {
+		t.Errorf("aoe_tower_explosion played %d times, want 2", got)
+	}
+}
@@ -1,9 +1,11 @@
 package towers
 
 import (
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerBasic{}
@@ -14,17 +16,13 @@ type TowerBasic struct {
 
 func NewTowerBasic(position lib.Vec2I) *TowerBasic {
 	return &TowerBasic{
-		Towercore: NewTowercore(1.0, 128.0, SpritesheetTowerBasic, position),
+		Towercore: NewTowercore(TowerTypeBasic, animation.Default.MustClip("tower_basic"), position),
 	}
 }
 
-func (t *TowerBasic) Price() int64 {
-	return 100
-}
-
 // Update implements Tower.
 func (t *TowerBasic) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	var furthestProgress float64 = -1
 	var furthestEnemy *enemy.Enemy
 	for _, e := range enemies {
@@ -45,18 +43,26 @@ func (t *TowerBasic) Update(em EnemyManager, pm ProjectileManager) error {
 		t.lookAt = dirToEnemy
 	}
 
-	if t.ShouldFire(lib.Dt()) && furthestEnemy != nil {
-		prj := NewProjectileBasic(
-			dirToEnemy,
-			t.position.ToVec2().Add(lib.NewVec2(32, 32)),
-			800.0,
-			12.0,
-			0.3,
-			int(1*t.damageUpgrades+1),
-		)
-		idx := pm.AddProjectile(prj)
-		prj.SelfIdx = idx
-		audio.Controller.Play("basic_tower_shoot", 0.05)
+	if t.ShouldFire(lib.StepDt()) && furthestEnemy != nil {
+		effects := t.effects()
+		for _, dir := range spread(dirToEnemy, 1+effects.Shots) {
+			prj := NewProjectileBasic(
+				dir,
+				t.position.ToVec2().Add(lib.NewVec2(32, 32)),
+				800.0,
+				12.0,
+				0.3,
+				t.Damage(),
+			)
+			prj.Source = TowerTypeBasic
+			prj.Owner = t.Towercore
+			prj.Pierce = effects.Pierce
+			prj.Slow = effects.Slow
+			idx := pm.AddProjectile(prj)
+			prj.SelfIdx = idx
+			particles.Default.Emit(particles.Muzzle, prj.position.Add(dir.Mul(28)), dir)
+		}
+		audio.Controller.Play("basic_tower_shoot")
 		t.shotThisTick = true
 	}
 
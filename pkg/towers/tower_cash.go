@@ -1,9 +1,13 @@
 package towers
 
 import (
+	"fmt"
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
+	"jamegam/pkg/combattext"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerCash{}
@@ -13,20 +17,15 @@ type TowerCash struct {
 }
 
 func NewTowerCash(position lib.Vec2I) *TowerCash {
-	tc := NewTowercore(3.0, 90.0, SpritesheetTowerCash, position)
-	tc.spriteFrames = 8
+	tc := NewTowercore(TowerTypeCash, animation.Default.MustClip("tower_cash"), position)
 	return &TowerCash{
 		Towercore: tc,
 	}
 }
 
-func (t *TowerCash) Price() int64 {
-	return 500
-}
-
 // Update implements Tower.
 func (t *TowerCash) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	hitEnemies := []*enemy.Enemy{} // only at max 8 enemies can be hit
 	for i, e := range enemies {
 		if i >= 8 {
@@ -36,12 +35,19 @@ func (t *TowerCash) Update(em EnemyManager, pm ProjectileManager) error {
 	}
 
 	var baseMana int32 = 1
-	if t.ShouldFire(lib.Dt()) && len(hitEnemies) > 0 {
-		mana := baseMana * (t.damageUpgrades + 1) * int32(len(hitEnemies))
+	if t.ShouldFire(lib.StepDt()) && len(hitEnemies) > 0 {
+		effects := t.effects()
+		mana := (baseMana + int32(effects.Mana)) * int32(len(hitEnemies))
 		em.AddMana(int64(mana))
+		for _, e := range hitEnemies {
+			slowDown(e, 0.5, effects.Slow)
+		}
 		// TODO: play sound
-		audio.Controller.Play("tower_cash_shot", 0)
+		audio.Controller.Play("tower_cash_shot")
 		t.shotThisTick = true
+		center := t.position.ToVec2().Add(lib.NewVec2(32, 32))
+		particles.Default.Burst(particles.CashPulse, center)
+		combattext.Default.Spawn(center, fmt.Sprintf("+%d", mana), combattext.StyleMana)
 	}
 
 	return nil
@@ -1,9 +1,11 @@
 package towers
 
 import (
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerIce{}
@@ -13,20 +15,16 @@ type TowerIce struct {
 }
 
 func NewTowerIce(position lib.Vec2I) *TowerIce {
-	tc := NewTowercore(2.0, 90.0, SpritesheetTowerIce, position)
+	tc := NewTowercore(TowerTypeIce, animation.Default.MustClip("tower_ice"), position)
 	tc.animSpeed = 0.1
 	return &TowerIce{
 		Towercore: tc,
 	}
 }
 
-func (t *TowerIce) Price() int64 {
-	return 200
-}
-
 // Update implements Tower.
 func (t *TowerIce) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	hitEnemies := []*enemy.Enemy{} // only at max 6 enemies can be hit
 	for i, e := range enemies {
 		if i >= 6 {
@@ -35,15 +33,24 @@ func (t *TowerIce) Update(em EnemyManager, pm ProjectileManager) error {
 		hitEnemies = append(hitEnemies, e)
 	}
 
-	if t.ShouldFire(lib.Dt()) && len(hitEnemies) > 0 {
-		// Spawn projectiles in a circle around the tower
-		speedMod := float32(0.5 - (0.05 * float64(t.speedUpgrades+t.damageUpgrades)))
+	if t.ShouldFire(lib.StepDt()) && len(hitEnemies) > 0 {
+		// Slow down the enemies in range, or freeze them. Shatter damages
+		// the ones that are still slowed from the last pulse.
+		effects := t.effects()
+		speedMod := float32(0.5 - (0.05 * float64(t.GetTotalUpgrades())))
 		for _, e := range hitEnemies {
-			e.SetSpeedMod(speedMod, 2)
+			if effects.Shatter > 0 && e.GetSpeedMod() < 1 {
+				t.damageEnemy(em, e, effects.Shatter)
+			}
+			if effects.Freeze > 0 {
+				slowDown(e, 0, effects.Freeze)
+			} else {
+				slowDown(e, speedMod, 2)
+			}
 		}
-		audio.Controller.Play("ice_tower_shoot", 0.05)
+		audio.Controller.Play("ice_tower_shoot")
 		t.shotThisTick = true
-		// TODO: visual effect
+		particles.Default.Burst(particles.IceBurst, t.position.ToVec2().Add(lib.NewVec2(32, 32)))
 	}
 
 	return nil
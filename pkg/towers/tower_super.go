@@ -1,9 +1,11 @@
 package towers
 
 import (
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerSuper{}
@@ -14,17 +16,13 @@ type TowerSuper struct {
 
 func NewTowerSuper(position lib.Vec2I) *TowerSuper {
 	return &TowerSuper{
-		Towercore: NewTowercore(0.2, 128.0, SpritesheetTowerSuper, position),
+		Towercore: NewTowercore(TowerTypeSuper, animation.Default.MustClip("tower_super"), position),
 	}
 }
 
-func (t *TowerSuper) Price() int64 {
-	return 500
-}
-
 // Update implements Tower.
 func (t *TowerSuper) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, path := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	var furthestProgress float64 = -1
 	var furthestEnemy *enemy.Enemy
 	for _, e := range enemies {
@@ -45,18 +43,26 @@ func (t *TowerSuper) Update(em EnemyManager, pm ProjectileManager) error {
 		t.lookAt = dirToEnemy
 	}
 
-	if t.ShouldFire(lib.Dt()) && furthestEnemy != nil {
-		prj := NewProjectileBasic(
-			dirToEnemy,
-			t.position.ToVec2().Add(lib.NewVec2(32, 32)),
-			800.0,
-			12.0,
-			0.3,
-			int(1*t.damageUpgrades+1),
-		)
-		idx := pm.AddProjectile(prj)
-		prj.SelfIdx = idx
-		audio.Controller.Play("basic_tower_shoot", 0.05)
+	if t.ShouldFire(lib.StepDt()) && furthestEnemy != nil {
+		effects := t.effects()
+		for _, dir := range spread(dirToEnemy, 1+effects.Shots) {
+			prj := NewProjectileBasic(
+				dir,
+				t.position.ToVec2().Add(lib.NewVec2(32, 32)),
+				800.0,
+				12.0,
+				0.3,
+				t.Damage(),
+			)
+			prj.Source = TowerTypeSuper
+			prj.Owner = t.Towercore
+			prj.Pierce = effects.Pierce
+			prj.Slow = effects.Slow
+			idx := pm.AddProjectile(prj)
+			prj.SelfIdx = idx
+			particles.Default.Emit(particles.Muzzle, prj.position.Add(dir.Mul(28)), dir)
+		}
+		audio.Controller.Play("basic_tower_shoot")
 		t.shotThisTick = true
 	}
 
@@ -1,9 +1,11 @@
 package towers
 
 import (
+	"jamegam/pkg/animation"
 	"jamegam/pkg/audio"
 	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/particles"
 )
 
 var _ Tower = &TowerTacks{}
@@ -13,20 +15,16 @@ type TowerTacks struct {
 }
 
 func NewTowerTacks(position lib.Vec2I) *TowerTacks {
-	tc := NewTowercore(2.0, 90.0, SpritesheetTowerTacks, position)
+	tc := NewTowercore(TowerTypeTacks, animation.Default.MustClip("tower_tacks"), position)
 	tc.animSpeed = 0.12
 	return &TowerTacks{
 		Towercore: tc,
 	}
 }
 
-func (t *TowerTacks) Price() int64 {
-	return 400
-}
-
 // Update implements Tower.
 func (t *TowerTacks) Update(em EnemyManager, pm ProjectileManager) error {
-	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.radius)
+	enemies, _ := em.GetEnemies(t.position.ToVec2().Add(lib.NewVec2(32, 32)), t.Radius())
 	var furthestProgress float64 = -1
 	var furthestEnemy *enemy.Enemy
 	for _, e := range enemies {
@@ -37,23 +35,32 @@ func (t *TowerTacks) Update(em EnemyManager, pm ProjectileManager) error {
 		}
 	}
 
-	if t.ShouldFire(lib.Dt()) && furthestEnemy != nil {
-		// Spawn projectiles in a circle around the tower
-		for i := 0; i < 8; i++ {
-			angle := float32(i) * 45
+	if t.ShouldFire(lib.StepDt()) && furthestEnemy != nil {
+		// Spawn projectiles in a circle around the tower, flying further
+		// with more range
+		effects := t.effects()
+		tacks := 8 + effects.Shots
+		lifetime := 0.13 * t.Radius() / Info(TowerTypeTacks).Radius
+		for i := 0; i < tacks; i++ {
+			angle := float32(i) * 360 / float32(tacks)
 			dirToEnemy := lib.NewVec2(1, 0).Rotate(angle)
 			prj := NewProjectileBasic(
 				dirToEnemy,
 				t.position.ToVec2().Add(lib.NewVec2(32, 32)),
 				800.0,
 				12.0,
-				0.13,
-				int(1*t.damageUpgrades+1),
+				lifetime,
+				t.Damage(),
 			)
+			prj.Source = TowerTypeTacks
+			prj.Owner = t.Towercore
+			prj.Pierce = effects.Pierce
+			prj.Slow = effects.Slow
 			idx := pm.AddProjectile(prj)
 			prj.SelfIdx = idx
+			particles.Default.Emit(particles.Muzzle, prj.position.Add(dirToEnemy.Mul(28)), dirToEnemy)
 		}
-		audio.Controller.Play("basic_tower_shoot", 0.05)
+		audio.Controller.Play("basic_tower_shoot")
 		t.shotThisTick = true
 	}
 
@@ -1,35 +1,34 @@
 package towers
 
 import (
-	"image"
+	"jamegam/pkg/animation"
+	"jamegam/pkg/enemy"
 	"jamegam/pkg/lib"
+	"jamegam/pkg/settings"
 	"math"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Towercore struct {
-	rof            float64
-	radius         float32
-	sprite         *ebiten.Image
-	position       lib.Vec2I
-	drawPosition   lib.Vec2
-	speedUpgrades  int32
-	damageUpgrades int32
-
-	tempSpeedBuff       float32
-	tempDamageBuff      float32
-	tempSpeedBuffTimer  time.Time
-	tempDamageBuffTimer time.Time
+	towerType    TowerType
+	anim         *animation.Animator
+	position     lib.Vec2I
+	drawPosition lib.Vec2
+
+	// levels counts the bought nodes per branch of the upgrade tree, branch
+	// is the one the tower follows, -1 until the first upgrade.
+	levels [BranchCount]int
+	branch int
+
+	tempSpeedBuff      float32
+	tempDamageBuff     float32
+	tempSpeedBuffLeft  float64 // seconds of game time
+	tempDamageBuffLeft float64
 
 	lastFiredAgo float64
 
-	shotThisTick     bool
-	spriteFrames     int
-	spriteSheetIdx   int
-	spriteSheetTimer float64
-	isAnimating      bool
+	shotThisTick bool
 
 	lookAt lib.Vec2
 
@@ -37,21 +36,21 @@ type Towercore struct {
 
 	settled    bool
 	settleAnim float64
+
+	kills       int64
+	damageDealt int64
 }
 
-func NewTowercore(rof float64, radius float32, sprite *ebiten.Image, position lib.Vec2I) *Towercore {
+func NewTowercore(towerType TowerType, clip *animation.Clip, position lib.Vec2I) *Towercore {
 	ret := &Towercore{
-		rof:            rof,
-		radius:         radius,
-		sprite:         sprite,
-		spriteFrames:   4,
-		position:       position,
-		speedUpgrades:  0,
-		damageUpgrades: 0,
-		animSpeed:      0.06,
-		settleAnim:     -0.2,
-		lastFiredAgo:   100,
-		lookAt:         lib.Vec2{X: 0, Y: 1},
+		towerType:    towerType,
+		anim:         animation.NewAnimator(clip),
+		position:     position,
+		branch:       -1,
+		animSpeed:    0.06,
+		settleAnim:   -0.2,
+		lastFiredAgo: 100,
+		lookAt:       lib.Vec2{X: 0, Y: 1},
 	}
 
 	ret.drawPosition = position.ToVec2() // TODO: for now, later some animation
@@ -59,38 +58,154 @@ func NewTowercore(rof float64, radius float32, sprite *ebiten.Image, position li
 	return ret
 }
 
+func (tc *Towercore) Type() TowerType {
+	return tc.towerType
+}
+
+func (tc *Towercore) Price() int64 {
+	return Info(tc.towerType).Price
+}
+
 func (tc *Towercore) Radius() float32 {
-	return tc.radius
+	return Info(tc.towerType).Radius + tc.effects().Radius
+}
+
+// FireRate returns the current seconds between two shots.
+func (tc *Towercore) FireRate() float64 {
+	return Info(tc.towerType).FireRate * tc.effects().FireRate
+}
+
+// Damage returns the current damage per hit.
+func (tc *Towercore) Damage() int {
+	if Info(tc.towerType).Damage == 0 {
+		return 0
+	}
+	return Info(tc.towerType).Damage + tc.effects().Damage
+}
+
+func (tc *Towercore) Kills() int64 {
+	return tc.kills
+}
+
+func (tc *Towercore) DamageDealt() int64 {
+	return tc.damageDealt
+}
+
+// recordHit counts the damage dealt by a projectile of this tower. It is safe
+// to call on projectiles without an owner.
+func (tc *Towercore) recordHit(damage int, killed bool) {
+	if tc == nil {
+		return
+	}
+	tc.damageDealt += int64(damage)
+	if killed {
+		tc.kills++
+	}
 }
 
 func (tc *Towercore) SetSpeedBuff(buff float32, duration float32) {
 	tc.tempSpeedBuff = buff
-	tc.tempSpeedBuffTimer = time.Now().Add(time.Duration(duration) * time.Second)
+	tc.tempSpeedBuffLeft = float64(duration)
 }
 
 func (tc *Towercore) SetDamageBuff(buff float32, duration float32) {
 	tc.tempDamageBuff = buff
-	tc.tempDamageBuffTimer = time.Now().Add(time.Duration(duration) * time.Second)
+	tc.tempDamageBuffLeft = float64(duration)
 }
 
 func (tc *Towercore) GetTotalUpgrades() int32 {
-	return tc.speedUpgrades + tc.damageUpgrades
+	total := 0
+	for _, level := range tc.levels {
+		total += level
+	}
+	return int32(total)
+}
+
+// BranchLevel returns the number of bought upgrades in the branch.
+func (tc *Towercore) BranchLevel(branch int) int {
+	if branch < 0 || branch >= BranchCount {
+		return 0
+	}
+	return tc.levels[branch]
+}
+
+// BranchLocked reports whether the tower follows another branch.
+func (tc *Towercore) BranchLocked(branch int) bool {
+	return tc.branch != -1 && tc.branch != branch
+}
+
+// NextUpgrade returns the next upgrade of the branch, and false if the branch
+// is fully upgraded.
+func (tc *Towercore) NextUpgrade(branch int) (Upgrade, bool) {
+	b, ok := UpgradeTree(tc.towerType).Branch(branch)
+	if !ok || tc.levels[branch] >= len(b.Nodes) {
+		return Upgrade{}, false
+	}
+	return b.Nodes[tc.levels[branch]], true
 }
 
-func (tc *Towercore) GetSpeedUpgrades() int32 {
-	return tc.speedUpgrades
+// Upgrade buys the next upgrade of the branch. The first upgrade decides the
+// branch the tower follows.
+func (tc *Towercore) Upgrade(branch int) {
+	if _, ok := tc.NextUpgrade(branch); !ok {
+		return
+	}
+	if tc.branch == -1 {
+		tc.branch = branch
+	}
+	tc.levels[branch]++
+}
+
+// UpgradeValue returns the summed cost of the bought upgrades.
+func (tc *Towercore) UpgradeValue() int64 {
+	var value int64
+	for i, b := range UpgradeTree(tc.towerType).Branches {
+		for _, u := range b.Nodes[:min(tc.levels[i], len(b.Nodes))] {
+			value += u.Cost
+		}
+	}
+	return value
 }
 
-func (tc *Towercore) GetDamageUpgrades() int32 {
-	return tc.damageUpgrades
+// effects returns the combined effects of the bought upgrades.
+func (tc *Towercore) effects() Upgrade {
+	total := Upgrade{FireRate: 1}
+	for i, b := range UpgradeTree(tc.towerType).Branches {
+		for _, u := range b.Nodes[:min(tc.levels[i], len(b.Nodes))] {
+			total = total.add(u)
+		}
+	}
+	return total
 }
 
-func (tc *Towercore) SpeedUpgrade() {
-	tc.speedUpgrades++
+// slowDown sets the speed modifier of the enemy, unless it is already slowed
+// down more.
+func slowDown(e *enemy.Enemy, speedMod float32, seconds float32) {
+	if seconds <= 0 || e.GetSpeedMod() < speedMod {
+		return
+	}
+	e.SetSpeedMod(speedMod, seconds)
 }
 
-func (tc *Towercore) DamageUpgrade() {
-	tc.damageUpgrades++
+// spread returns the directions of shots fired at once, fanned out around the
+// given direction.
+func spread(dir lib.Vec2, shots int) []lib.Vec2 {
+	const angle = 10 // degrees between two shots
+	dirs := make([]lib.Vec2, shots)
+	for i := range dirs {
+		dirs[i] = dir.Rotate(angle * (float32(i) - float32(shots-1)/2))
+	}
+	return dirs
+}
+
+// damageEnemy deals damage to an enemy directly, without a projectile.
+func (tc *Towercore) damageEnemy(em EnemyManager, e *enemy.Enemy, damage int) {
+	newHealth := e.GetHealth() - damage
+	tc.recordHit(min(damage, e.GetHealth()), newHealth <= 0)
+	e.SetHealth(newHealth)
+	if e.IsDead {
+		em.RegisterKill(tc.towerType)
+	}
 }
 
 func (tc *Towercore) Draw(screen *ebiten.Image) {
@@ -108,7 +223,7 @@ func (tc *Towercore) Draw(screen *ebiten.Image) {
 		dt = 1.0 / fps
 	}
 
-	if !tc.settled {
+	if !tc.settled && !settings.Current.ReducedMotion {
 		tc.settleAnim += 6.7 * dt
 		geom.Translate(0, 12*math.Sin(tc.settleAnim))
 		if tc.settleAnim > math.Pi {
@@ -118,23 +233,10 @@ func (tc *Towercore) Draw(screen *ebiten.Image) {
 
 	if tc.shotThisTick {
 		tc.shotThisTick = false
-		tc.isAnimating = true
+		tc.anim.Restart()
 	}
-
-	if tc.isAnimating {
-		tc.spriteSheetTimer += dt
-		if tc.spriteSheetTimer > 0.06 {
-			tc.spriteSheetTimer = 0
-			tc.spriteSheetIdx = (tc.spriteSheetIdx + 1) % tc.spriteFrames
-		}
-
-		if tc.spriteSheetIdx == tc.spriteFrames-1 {
-			tc.spriteSheetIdx = 0
-			tc.isAnimating = false
-		}
-	}
-	subsprite := tc.sprite.SubImage(image.Rect(tc.spriteSheetIdx*16, 0, (tc.spriteSheetIdx+1)*16, 16)).(*ebiten.Image)
-	screen.DrawImage(subsprite, &ebiten.DrawImageOptions{GeoM: geom})
+	tc.anim.Update(dt)
+	screen.DrawImage(tc.anim.Frame(), &ebiten.DrawImageOptions{GeoM: geom})
 
 	// vector.StrokeCircle(
 	// 	screen,
@@ -148,19 +250,20 @@ func (tc *Towercore) Draw(screen *ebiten.Image) {
 
 // WARN: ShouldFire must be called every tick to determine if the tower should fire
 func (tc *Towercore) ShouldFire(dt float64) bool {
-	if tc.lastFiredAgo >= tc.rof*(math.Pow(0.9, float64(tc.speedUpgrades))) {
-		tc.lastFiredAgo = 0
-		return true
-	}
-
-	// check and reset temporary buffs
-	if tc.tempSpeedBuffTimer.Before(time.Now()) {
+	// count down and reset temporary buffs
+	tc.tempSpeedBuffLeft -= dt
+	if tc.tempSpeedBuffLeft <= 0 {
 		tc.tempSpeedBuff = 0
 	}
-	if tc.tempDamageBuffTimer.Before(time.Now()) {
+	tc.tempDamageBuffLeft -= dt
+	if tc.tempDamageBuffLeft <= 0 {
 		tc.tempDamageBuff = 0
 	}
 
+	if tc.lastFiredAgo >= tc.FireRate() {
+		tc.lastFiredAgo = 0
+		return true
+	}
 	tc.lastFiredAgo += dt
 	return false
 }
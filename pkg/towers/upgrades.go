@@ -0,0 +1,166 @@
+package towers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"jamegam/pkg/assets"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/lib"
+)
+
+// upgradesFile defines the upgrade trees of all tower types, see treeDef.
+const upgradesFile = "upgrades.json"
+
+// BranchCount is the number of branches of every upgrade tree, one per
+// upgrade button.
+const BranchCount = 2
+
+// Upgrade is a node of an upgrade tree. The effects of all bought nodes of a
+// tower add up, and every tower uses the ones that make sense for it.
+type Upgrade struct {
+	// Key names the node in message keys, prefixed with the tower key on load.
+	Key  string `json:"key"`
+	Cost int64  `json:"cost"`
+
+	Damage   int     `json:"damage"`    // added damage per hit
+	FireRate float64 `json:"fire_rate"` // multiplies the seconds between shots, 0 keeps them
+	Radius   float32 `json:"radius"`    // added range in pixels
+	Pierce   int     `json:"pierce"`    // more enemies a projectile passes through
+	Shots    int     `json:"shots"`     // more projectiles per shot
+	Blast    float32 `json:"blast"`     // added explosion radius in pixels
+	Slow     float32 `json:"slow"`      // seconds hit enemies are slowed to half speed
+	Freeze   float32 `json:"freeze"`    // seconds hit enemies stop instead of being slowed
+	Shatter  int     `json:"shatter"`   // damage to enemies that are already slowed
+	Mana     int     `json:"mana"`      // more mana per enemy in range
+}
+
+// Name returns the display name of the upgrade.
+func (u Upgrade) Name() string {
+	return i18n.T("upgrade." + u.Key)
+}
+
+// Description returns what the upgrade does in the current language.
+func (u Upgrade) Description() string {
+	return i18n.T("upgrade_desc." + u.Key)
+}
+
+// add combines the effects of two upgrades.
+func (u Upgrade) add(o Upgrade) Upgrade {
+	u.Damage += o.Damage
+	if o.FireRate != 0 {
+		u.FireRate *= o.FireRate
+	}
+	u.Radius += o.Radius
+	u.Pierce += o.Pierce
+	u.Shots += o.Shots
+	u.Blast += o.Blast
+	u.Slow += o.Slow
+	u.Freeze += o.Freeze
+	u.Shatter += o.Shatter
+	u.Mana += o.Mana
+	return u
+}
+
+// Branch is a path of upgrades which are bought one after another.
+type Branch struct {
+	// Key names the branch in message keys, prefixed with the tower key on load.
+	Key string `json:"key"`
+	// Icon is the image file shown on the upgrade button of the branch.
+	Icon  string    `json:"icon"`
+	Nodes []Upgrade `json:"nodes"`
+}
+
+// Name returns the display name of the branch.
+func (b Branch) Name() string {
+	return i18n.T("upgrade." + b.Key)
+}
+
+// Tree holds the upgrade branches of a tower type. A tower follows the first
+// branch it is upgraded in, the other one is locked.
+type Tree struct {
+	Branches []Branch
+}
+
+// Branch returns the branch with the given index, and false if there is none.
+func (t Tree) Branch(i int) (Branch, bool) {
+	if i < 0 || i >= len(t.Branches) {
+		return Branch{}, false
+	}
+	return t.Branches[i], true
+}
+
+// treeDef is the upgrade file, mapping tower keys (see TowerType.key) to their
+// branches.
+type treeDef map[string][]Branch
+
+var trees = map[TowerType]Tree{}
+
+func init() {
+	lib.Must(loadTrees())
+	assets.Watch(upgradesFile, loadTrees)
+}
+
+func loadTrees() error {
+	reader, err := assets.Open(upgradesFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	parsed, err := parseTrees(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", upgradesFile, err)
+	}
+	trees = parsed
+	return nil
+}
+
+// parseTrees reads and checks an upgrade file.
+func parseTrees(r io.Reader) (map[TowerType]Tree, error) {
+	def := treeDef{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, err
+	}
+	parsed := make(map[TowerType]Tree)
+	for towerKey, branches := range def {
+		towerType, ok := towerTypeByKey(towerKey)
+		if !ok {
+			return nil, fmt.Errorf("unknown tower %q", towerKey)
+		}
+		if len(branches) != BranchCount {
+			return nil, fmt.Errorf("tower %s has %d branches, want %d", towerKey, len(branches), BranchCount)
+		}
+		for i := range branches {
+			b := &branches[i]
+			if b.Key == "" || b.Icon == "" || len(b.Nodes) == 0 {
+				return nil, fmt.Errorf("tower %s: branch %d needs a key, an icon and nodes", towerKey, i)
+			}
+			b.Key = towerKey + "." + b.Key
+			for j := range b.Nodes {
+				if b.Nodes[j].Key == "" || b.Nodes[j].Cost <= 0 {
+					return nil, fmt.Errorf("branch %s: node %d needs a key and a cost", b.Key, j)
+				}
+				b.Nodes[j].Key = towerKey + "." + b.Nodes[j].Key
+			}
+		}
+		parsed[towerType] = Tree{Branches: branches}
+	}
+	return parsed, nil
+}
+
+// UpgradeTree returns the upgrade tree of the tower type, which is empty for
+// towers that can't be upgraded.
+func UpgradeTree(t TowerType) Tree {
+	return trees[t]
+}
+
+func towerTypeByKey(key string) (TowerType, bool) {
+	for t := TowerTypeBasic; t <= TowerTypeSuper; t++ {
+		if t.key() == key {
+			return t, true
+		}
+	}
+	return TowerTypeNone, false
+}
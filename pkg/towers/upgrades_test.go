@@ -0,0 +1,61 @@
+package towers
+
+import (
+	"jamegam/pkg/assets"
+	"strings"
+	"testing"
+)
+
+func TestEveryTowerHasUpgradeTree(t *testing.T) {
+	for towerType := TowerTypeBasic; towerType <= TowerTypeSuper; towerType++ {
+		if n := len(UpgradeTree(towerType).Branches); n != BranchCount {
+			t.Errorf("%s has %d branches", towerType.key(), n)
+		}
+	}
+}
+
+func TestEveryBranchIconExists(t *testing.T) {
+	for towerType := TowerTypeBasic; towerType <= TowerTypeSuper; towerType++ {
+		for _, branch := range UpgradeTree(towerType).Branches {
+			file, err := assets.Open(branch.Icon)
+			if err != nil {
+				t.Errorf("branch %s: %v", branch.Key, err)
+				continue
+			}
+			file.Close()
+		}
+	}
+}
+
+func TestParseTreesPrefixesKeys(t *testing.T) {
+	parsed, err := parseTrees(strings.NewReader(`{"ice": [
+		{"key": "a", "icon": "i", "nodes": [{"key": "x", "cost": 10, "freeze": 0.5}]},
+		{"key": "b", "icon": "i", "nodes": [{"key": "y", "cost": 20, "fire_rate": 0.5}, {"key": "z", "cost": 30, "fire_rate": 0.5}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch, ok := parsed[TowerTypeIce].Branch(1)
+	if !ok || branch.Key != "ice.b" || branch.Nodes[1].Key != "ice.z" {
+		t.Fatalf("got branch %+v", branch)
+	}
+	total := Upgrade{FireRate: 1}.add(branch.Nodes[0]).add(branch.Nodes[1])
+	if total.FireRate != 0.25 {
+		t.Errorf("fire rate factor %v, want 0.25", total.FireRate)
+	}
+}
+
+func TestParseTreesRejects(t *testing.T) {
+	for _, def := range []string{
+		`{"nope": []}`,
+		`{"basic": [{"key": "a", "icon": "i", "nodes": [{"key": "x", "cost": 1}]}]}`,
+		`{"basic": [{"key": "a", "nodes": [{"key": "x", "cost": 1}]}, {"key": "b", "icon": "i", "nodes": [{"key": "y", "cost": 1}]}]}`,
+		`{"basic": [{"key": "a", "icon": "i", "nodes": []}, {"key": "b", "icon": "i", "nodes": [{"key": "x", "cost": 1}]}]}`,
+		`{"basic": [{"key": "a", "icon": "i", "nodes": [{"key": "x"}]}, {"key": "b", "icon": "i", "nodes": [{"key": "y", "cost": 1}]}]}`,
+		`{"basic": [{"key": "a", "icon": "i", "nodes": [{"key": "x", "cost": 1, "peirce": 1}]}, {"key": "b", "icon": "i", "nodes": [{"key": "y", "cost": 1}]}]}`,
+	} {
+		if _, err := parseTrees(strings.NewReader(def)); err == nil {
+			t.Errorf("accepted %s", def)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package ui
+
+import "image"
+
+// Grid lays out equally sized cells in rows and columns.
+type Grid struct {
+	Origin image.Point
+	Cell   image.Point
+	Gap    image.Point
+}
+
+// At returns the rect of the cell in the given column and row.
+func (g Grid) At(col, row int) image.Rectangle {
+	min := g.Origin.Add(image.Pt(col*(g.Cell.X+g.Gap.X), row*(g.Cell.Y+g.Gap.Y)))
+	return image.Rectangle{Min: min, Max: min.Add(g.Cell)}
+}
+
+// Column returns count rects of the given size below each other.
+func Column(origin, size image.Point, gap, count int) []image.Rectangle {
+	g := Grid{Origin: origin, Cell: size, Gap: image.Pt(0, gap)}
+	rects := make([]image.Rectangle, count)
+	for i := range rects {
+		rects[i] = g.At(0, i)
+	}
+	return rects
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestGridAt(t *testing.T) {
+	g := Grid{Origin: image.Pt(24, 786), Cell: image.Pt(96, 96), Gap: image.Pt(14, 18)}
+	if got, want := g.At(0, 0), image.Rect(24, 786, 120, 882); got != want {
+		t.Errorf("At(0, 0) = %v, want %v", got, want)
+	}
+	if got, want := g.At(2, 1), image.Rect(244, 900, 340, 996); got != want {
+		t.Errorf("At(2, 1) = %v, want %v", got, want)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	rects := Column(image.Pt(312, 240), image.Pt(400, 50), 10, 3)
+	if len(rects) != 3 || rects[2].Min.Y != 360 || rects[2].Dx() != 400 {
+		t.Errorf("Column = %v", rects)
+	}
+}
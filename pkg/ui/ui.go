@@ -0,0 +1,304 @@
+// Package ui is a small retained mode widget toolkit. Widgets are created
+// once, added to a Root and updated and drawn by it every frame. The root
+// takes care of hover and pressed states, keyboard focus, hotkeys, click
+// sounds and tooltips.
+package ui
+
+import (
+	"image"
+	"image/color"
+	"jamegam/pkg/audio"
+	"jamegam/pkg/display"
+	"jamegam/pkg/i18n"
+	"jamegam/pkg/input"
+	"jamegam/pkg/settings"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Colors shared by all widgets.
+var (
+	ColorBackground = color.RGBA{60, 60, 60, 255}
+	ColorOutline    = color.RGBA{100, 255, 100, 255}
+	ColorFill       = color.RGBA{70, 160, 70, 255}
+	ColorHover      = color.RGBA{255, 255, 255, 40}
+	ColorFocus      = color.RGBA{255, 220, 80, 255}
+	ColorDisabled   = color.RGBA{0, 0, 0, 120}
+	ColorText       = color.RGBA{255, 255, 255, 255}
+)
+
+// fontSize is the size of all text at a text scale of 100%.
+const fontSize = 20
+
+// LineSpacing is the distance between lines relative to the font size.
+const LineSpacing = 1.3
+
+// ScaledFontSize is the font size of all text, grown by the text scale
+// setting.
+func ScaledFontSize() float64 {
+	return fontSize * settings.Current.TextScale
+}
+
+// FitFontSize returns ScaledFontSize, shrunk in whole pixels where needed so
+// txt fits into size. A zero width or height is not limited.
+func FitFontSize(txt string, size image.Point) float64 {
+	fitted := ScaledFontSize()
+	for fitted > fontSize/2 {
+		w, h := text.Measure(txt, i18n.Face(fitted), fitted*LineSpacing)
+		if (size.X == 0 || w <= float64(size.X)) && (size.Y == 0 || h <= float64(size.Y)) {
+			break
+		}
+		fitted = math.Floor(fitted - 1)
+	}
+	return fitted
+}
+
+// Widget is anything the root can lay out, update and draw.
+type Widget interface {
+	State() *Base
+	Draw(screen *ebiten.Image)
+}
+
+// clicker is implemented by widgets that react to clicks and hotkeys.
+type clicker interface {
+	click()
+}
+
+// dragger is implemented by widgets that follow the held mouse button.
+type dragger interface {
+	drag(x, y int)
+}
+
+// container is implemented by widgets holding other widgets.
+type container interface {
+	children() []Widget
+}
+
+// Base holds the state common to all widgets, embed it in every widget.
+type Base struct {
+	Rect image.Rectangle
+	// Tooltip is shown when hovering the widget, TooltipFunc takes
+	// precedence for texts that change.
+	Tooltip     string
+	TooltipFunc func() string
+	// Action clicks the widget like the mouse does when its key is pressed.
+	Action input.Action
+	// Sound is played on click, defaults to "click". Set Silent to play none.
+	Sound  string
+	Silent bool
+
+	Hidden   bool
+	Disabled bool
+
+	hovered bool
+	pressed bool
+	focused bool
+}
+
+func (b *Base) State() *Base {
+	return b
+}
+
+func (b *Base) Hovered() bool {
+	return b.hovered
+}
+
+func (b *Base) Pressed() bool {
+	return b.pressed
+}
+
+func (b *Base) Focused() bool {
+	return b.focused
+}
+
+func (b *Base) tooltip() string {
+	if b.TooltipFunc != nil {
+		return b.TooltipFunc()
+	}
+	return b.Tooltip
+}
+
+func (b *Base) playSound() {
+	if b.Silent {
+		return
+	}
+	sound := b.Sound
+	if sound == "" {
+		sound = "click"
+	}
+	audio.Controller.PlayUI(sound)
+}
+
+// tooltipDelay is the hover time in seconds before a tooltip shows.
+const tooltipDelay = 0.4
+
+// Root owns a set of widgets and handles their input.
+type Root struct {
+	widgets []Widget
+
+	// ManualFocus disables moving the focus with ActionFocusNext, for screens
+	// that move it with Focus instead.
+	ManualFocus bool
+
+	hovered Widget
+	pressed Widget
+	focused Widget
+
+	hoverTime float64
+}
+
+func NewRoot(widgets ...Widget) *Root {
+	return &Root{widgets: widgets}
+}
+
+// Add adds widgets, later widgets are drawn on top of earlier ones.
+func (r *Root) Add(widgets ...Widget) {
+	r.widgets = append(r.widgets, widgets...)
+}
+
+// visible returns all visible widgets, including those inside containers,
+// in drawing order.
+func (r *Root) visible() []Widget {
+	var ret []Widget
+	var walk func(widgets []Widget)
+	walk = func(widgets []Widget) {
+		for _, w := range widgets {
+			if w.State().Hidden {
+				continue
+			}
+			ret = append(ret, w)
+			if c, ok := w.(container); ok {
+				walk(c.children())
+			}
+		}
+	}
+	walk(r.widgets)
+	return ret
+}
+
+// Update handles the input for all widgets. It returns whether the cursor is
+// over a widget, so clicks there should not reach the game world.
+func (r *Root) Update(dt float64) bool {
+	x, y := display.CursorPosition()
+	widgets := r.visible()
+
+	var hovered Widget
+	for _, w := range widgets {
+		if (image.Point{x, y}).In(w.State().Rect) {
+			hovered = w // the last one is drawn on top
+		}
+	}
+	if hovered != r.hovered {
+		r.hoverTime = 0
+	}
+	r.hovered = hovered
+	r.hoverTime += dt
+
+	// Mouse
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		r.pressed = nil
+		if hovered != nil && !hovered.State().Disabled && isInteractive(hovered) {
+			r.pressed = hovered
+			r.Focus(hovered)
+		}
+	}
+	if r.pressed != nil {
+		if d, ok := r.pressed.(dragger); ok && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			d.drag(x, y)
+		}
+		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+			if c, ok := r.pressed.(clicker); ok && r.pressed == hovered {
+				r.pressed.State().playSound()
+				c.click()
+			}
+			r.pressed = nil
+		}
+	}
+
+	// Keyboard focus
+	if !r.ManualFocus && input.JustPressed(input.ActionFocusNext) {
+		r.moveFocus(widgets, ebiten.IsKeyPressed(ebiten.KeyShift))
+	}
+	if r.focused != nil && !r.focused.State().Disabled && input.JustPressed(input.ActionConfirm) {
+		if c, ok := r.focused.(clicker); ok {
+			r.focused.State().playSound()
+			c.click()
+		}
+	}
+
+	// Actions
+	for _, w := range widgets {
+		c, ok := w.(clicker)
+		if !ok || w.State().Disabled {
+			continue
+		}
+		if action := w.State().Action; action != "" && input.JustPressed(action) {
+			w.State().playSound()
+			c.click()
+		}
+	}
+
+	for _, w := range widgets {
+		b := w.State()
+		b.hovered = w == hovered
+		b.pressed = w == r.pressed && b.hovered
+		b.focused = w == r.focused
+	}
+	return hovered != nil
+}
+
+func isInteractive(w Widget) bool {
+	_, click := w.(clicker)
+	_, drag := w.(dragger)
+	return click || drag
+}
+
+// Focus gives the keyboard focus to the widget, nil clears it.
+func (r *Root) Focus(w Widget) {
+	r.focused = w
+}
+
+// moveFocus focuses the next, or previous, interactive widget.
+func (r *Root) moveFocus(widgets []Widget, backwards bool) {
+	focusable := []Widget{}
+	current := -1
+	for _, w := range widgets {
+		if !isInteractive(w) || w.State().Disabled {
+			continue
+		}
+		if w == r.focused {
+			current = len(focusable)
+		}
+		focusable = append(focusable, w)
+	}
+	if len(focusable) == 0 {
+		return
+	}
+	step := 1
+	if backwards {
+		step = -1
+	}
+	next := (current + step + len(focusable)) % len(focusable)
+	if current == -1 && backwards {
+		next = len(focusable) - 1
+	}
+	r.Focus(focusable[next])
+}
+
+// Draw draws all widgets and the tooltip of the hovered one.
+func (r *Root) Draw(screen *ebiten.Image) {
+	for _, w := range r.widgets {
+		if !w.State().Hidden {
+			w.Draw(screen)
+		}
+	}
+	if r.hovered != nil && r.hoverTime >= tooltipDelay {
+		if tip := r.hovered.State().tooltip(); tip != "" {
+			x, y := display.CursorPosition()
+			drawTooltip(screen, tip, x, y)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"jamegam/pkg/i18n"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// ========================================
+// Button
+// ========================================
+
+var _ Widget = &Button{}
+
+// Button is a text button.
+type Button struct {
+	Base
+	Label string
+	// LabelFunc takes precedence over Label for labels that change.
+	LabelFunc func() string
+	OnClick   func()
+}
+
+func NewButton(rect image.Rectangle, label string, onClick func()) *Button {
+	return &Button{Base: Base{Rect: rect}, Label: label, OnClick: onClick}
+}
+
+func (b *Button) click() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Draw(screen *ebiten.Image) {
+	fillRect(screen, b.Rect, ColorBackground)
+	drawState(screen, &b.Base)
+	strokeRect(screen, b.Rect, ColorOutline)
+	label := b.Label
+	if b.LabelFunc != nil {
+		label = b.LabelFunc()
+	}
+	drawLabel(screen, label, b.Rect)
+	drawFocus(screen, &b.Base)
+}
+
+// ========================================
+// IconButton
+// ========================================
+
+var _ Widget = &IconButton{}
+
+// IconButton is a button showing pixel art, scaled up by Scale.
+type IconButton struct {
+	Base
+	// Background is drawn below the icon, e.g. an inventory slot.
+	Background *ebiten.Image
+	Icon       *ebiten.Image
+	// IconFunc takes precedence over Icon for icons that change.
+	IconFunc   func() *ebiten.Image
+	IconOffset image.Point
+	Scale      float64
+	// Selected draws the button highlighted, e.g. the chosen tower.
+	Selected func() bool
+	OnClick  func()
+}
+
+func NewIconButton(rect image.Rectangle, background, icon *ebiten.Image, onClick func()) *IconButton {
+	return &IconButton{
+		Base:       Base{Rect: rect},
+		Background: background,
+		Icon:       icon,
+		IconOffset: image.Pt(16, 16),
+		Scale:      4,
+		OnClick:    onClick,
+	}
+}
+
+func (b *IconButton) click() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *IconButton) icon() *ebiten.Image {
+	if b.IconFunc != nil {
+		return b.IconFunc()
+	}
+	return b.Icon
+}
+
+func (b *IconButton) Draw(screen *ebiten.Image) {
+	if b.Background != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(b.Scale, b.Scale)
+		op.GeoM.Translate(float64(b.Rect.Min.X), float64(b.Rect.Min.Y))
+		screen.DrawImage(b.Background, op)
+	}
+	if icon := b.icon(); icon != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(b.Scale, b.Scale)
+		offset := b.Rect.Min.Add(b.IconOffset)
+		if b.pressed {
+			offset.Y += 4
+		}
+		op.GeoM.Translate(float64(offset.X), float64(offset.Y))
+		screen.DrawImage(icon, op)
+	}
+	drawState(screen, &b.Base)
+	if b.Selected != nil && b.Selected() {
+		strokeRect(screen, b.Rect, ColorOutline)
+	}
+	drawFocus(screen, &b.Base)
+}
+
+// ========================================
+// Label
+// ========================================
+
+var _ Widget = &Label{}
+
+// Label is text drawn at the top left of its rect. Text grown by the text
+// scale shrinks again where it would leave the rect; an empty rect from
+// NewLabel does not limit it.
+type Label struct {
+	Base
+	Text string
+	// TextFunc takes precedence over Text for texts that change.
+	TextFunc func() string
+	Color    color.Color
+	// ColorFunc takes precedence over Color for colors that change.
+	ColorFunc func() color.Color
+}
+
+func NewLabel(pos image.Point, txt string) *Label {
+	return &Label{Base: Base{Rect: image.Rectangle{Min: pos, Max: pos}}, Text: txt, Color: ColorText}
+}
+
+func (l *Label) Draw(screen *ebiten.Image) {
+	txt := l.Text
+	if l.TextFunc != nil {
+		txt = l.TextFunc()
+	}
+	clr := l.Color
+	if l.ColorFunc != nil {
+		clr = l.ColorFunc()
+	}
+	drawTextSize(screen, txt, FitFontSize(txt, l.Rect.Size()), l.Rect.Min.X, l.Rect.Min.Y, clr)
+}
+
+// ========================================
+// Panel
+// ========================================
+
+var _ Widget = &Panel{}
+
+// Panel groups widgets on a background image or color.
+type Panel struct {
+	Base
+	Image *ebiten.Image
+	Color color.Color
+	Items []Widget
+}
+
+func NewPanel(rect image.Rectangle, items ...Widget) *Panel {
+	return &Panel{Base: Base{Rect: rect}, Items: items}
+}
+
+func (p *Panel) children() []Widget {
+	return p.Items
+}
+
+func (p *Panel) Draw(screen *ebiten.Image) {
+	if p.Color != nil {
+		fillRect(screen, p.Rect, p.Color)
+	}
+	if p.Image != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(p.Rect.Min.X), float64(p.Rect.Min.Y))
+		screen.DrawImage(p.Image, op)
+	}
+	for _, w := range p.Items {
+		if !w.State().Hidden {
+			w.Draw(screen)
+		}
+	}
+}
+
+// ========================================
+// Slider
+// ========================================
+
+var _ Widget = &Slider{}
+
+// Slider picks a value between 0 and 1 by dragging.
+type Slider struct {
+	Base
+	Label    string
+	Value    func() float64
+	OnChange func(value float64)
+	// OnRelease is called once dragging ends, e.g. to save the value.
+	OnRelease func()
+
+	dragging bool
+}
+
+func NewSlider(rect image.Rectangle, label string, value func() float64, onChange func(float64)) *Slider {
+	return &Slider{Base: Base{Rect: rect, Silent: true}, Label: label, Value: value, OnChange: onChange}
+}
+
+func (s *Slider) drag(x, y int) {
+	s.dragging = true
+	value := float64(x-s.Rect.Min.X) / float64(s.Rect.Dx())
+	if s.OnChange != nil {
+		s.OnChange(min(1, max(0, value)))
+	}
+}
+
+// click is called when the mouse is released over the slider.
+func (s *Slider) click() {
+	s.dragging = false
+	if s.OnRelease != nil {
+		s.OnRelease()
+	}
+}
+
+func (s *Slider) Draw(screen *ebiten.Image) {
+	value := s.Value()
+	fillRect(screen, s.Rect, ColorBackground)
+	fill := s.Rect
+	fill.Max.X = fill.Min.X + int(float64(s.Rect.Dx())*value)
+	fillRect(screen, fill, ColorFill)
+	drawState(screen, &s.Base)
+	strokeRect(screen, s.Rect, ColorOutline)
+	drawLabel(screen, fmt.Sprintf("%s: %d%%", s.Label, int(value*100)), s.Rect)
+	drawFocus(screen, &s.Base)
+}
+
+// ========================================
+// Toggle
+// ========================================
+
+var _ Widget = &Toggle{}
+
+// Toggle is a button switching a setting on and off.
+type Toggle struct {
+	Base
+	Label    string
+	Value    func() bool
+	OnToggle func(value bool)
+}
+
+func NewToggle(rect image.Rectangle, label string, value func() bool, onToggle func(bool)) *Toggle {
+	return &Toggle{Base: Base{Rect: rect}, Label: label, Value: value, OnToggle: onToggle}
+}
+
+func (t *Toggle) click() {
+	if t.OnToggle != nil {
+		t.OnToggle(!t.Value())
+	}
+}
+
+func (t *Toggle) Draw(screen *ebiten.Image) {
+	state := i18n.T("common.off")
+	if t.Value() {
+		state = i18n.T("common.on")
+	}
+	fillRect(screen, t.Rect, ColorBackground)
+	drawState(screen, &t.Base)
+	strokeRect(screen, t.Rect, ColorOutline)
+	drawLabel(screen, fmt.Sprintf("%s: %s", t.Label, state), t.Rect)
+	drawFocus(screen, &t.Base)
+}
+
+// ========================================
+// Drawing helpers
+// ========================================
+
+func fillRect(screen *ebiten.Image, r image.Rectangle, clr color.Color) {
+	vector.DrawFilledRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), clr, false)
+}
+
+func strokeRect(screen *ebiten.Image, r image.Rectangle, clr color.Color) {
+	vector.StrokeRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), 3, clr, false)
+}
+
+// drawState shades hovered and disabled widgets.
+func drawState(screen *ebiten.Image, b *Base) {
+	if b.Disabled {
+		fillRect(screen, b.Rect, ColorDisabled)
+	} else if b.hovered {
+		fillRect(screen, b.Rect, ColorHover)
+	}
+}
+
+func drawFocus(screen *ebiten.Image, b *Base) {
+	if b.focused {
+		strokeRect(screen, b.Rect.Inset(-4), ColorFocus)
+	}
+}
+
+// drawLabel draws the label of a button like widget vertically centered in
+// rect, shrunk to fit if the text scale makes it too large.
+func drawLabel(screen *ebiten.Image, txt string, rect image.Rectangle) {
+	size := FitFontSize(txt, rect.Size().Sub(image.Pt(40, 8)))
+	drawTextSize(screen, txt, size, rect.Min.X+20, rect.Min.Y+(rect.Dy()-int(size*LineSpacing))/2, ColorText)
+}
+
+func drawTextSize(screen *ebiten.Image, txt string, size float64, x, y int, clr color.Color) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(clr)
+	op.LineSpacing = size * LineSpacing
+	text.Draw(screen, txt, i18n.Face(size), op)
+}
+
+// drawTooltip draws a text box next to the cursor, kept on the screen. It
+// follows the text scale setting.
+func drawTooltip(screen *ebiten.Image, tip string, x, y int) {
+	const padding = 8
+	size := ScaledFontSize()
+	lines := strings.Count(tip, "\n") + 1
+	w, _ := text.Measure(tip, i18n.Face(size), size*LineSpacing)
+	h := float64(lines) * size * LineSpacing
+	box := image.Rect(0, 0, int(w)+2*padding, int(h)+2*padding).Add(image.Pt(x+16, y+16))
+
+	bounds := screen.Bounds()
+	if box.Max.X > bounds.Max.X {
+		box = box.Add(image.Pt(bounds.Max.X-box.Max.X, 0))
+	}
+	if box.Max.Y > bounds.Max.Y {
+		box = box.Add(image.Pt(0, y-16-box.Max.Y)) // above the cursor instead
+	}
+	fillRect(screen, box, color.RGBA{20, 20, 20, 230})
+	strokeRect(screen, box, ColorOutline)
+	drawTextSize(screen, tip, size, box.Min.X+padding, box.Min.Y+padding, ColorText)
+}
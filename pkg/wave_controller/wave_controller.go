@@ -1,14 +1,35 @@
 package wavecontroller
 
 import (
-	"fmt"
 	"jamegam/pkg/enemy"
+	"math"
 	"math/rand"
 )
 
+// enemyOdds describes how likely an enemy type is picked by the wave
+// generator, and from which wave on it may appear at all.
+type enemyOdds struct {
+	enemyType  enemy.EnemyType
+	weight     int
+	unlockWave int // 0 means always available, otherwise endless mode only
+}
+
+// enemyTable must be sorted by enemy cost, cheapest first, since
+// unaffordable picks fall back to the next cheaper enemy.
+var enemyTable = []enemyOdds{
+	{enemy.EnemyTypeBasic, 75, 0},
+	{enemy.EnemyTypeFast, 15, 0},
+	{enemy.EnemyTypeTank, 10, 0},
+	{enemy.EnemyTypePhantom, 6, 12},
+	{enemy.EnemyTypeBrute, 5, 8},
+}
+
 type WaveController struct {
 	resources int64
 	peacetime bool
+
+	endless bool
+	wave    int
 }
 
 func NewWaveController(starting_resources int64) *WaveController {
@@ -27,50 +48,96 @@ func (e *WaveController) GetResources() int64 {
 	return e.resources
 }
 
+// SetEndless enables or disables endless mode, in which enemy stats scale
+// with the wave number and new enemy types unlock over time.
+func (e *WaveController) SetEndless(endless bool) {
+	e.endless = endless
+}
+
+func (e *WaveController) IsEndless() bool {
+	return e.endless
+}
+
+// GetWave returns the number of the last generated wave.
+func (e *WaveController) GetWave() int {
+	return e.wave
+}
+
+// EnemyModifiers returns the health and speed multipliers for enemies of the
+// current wave.
+func (e *WaveController) EnemyModifiers() (health, speed float64) {
+	if !e.endless || e.wave <= 1 {
+		return 1, 1
+	}
+	health = 1 + 0.12*float64(e.wave-1)
+	speed = math.Min(1.5, 1+0.015*float64(e.wave-1))
+	return health, speed
+}
+
+// NewEnemyTypes returns the enemy types that appear for the first time in the
+// current wave.
+func (e *WaveController) NewEnemyTypes() []enemy.EnemyType {
+	ret := []enemy.EnemyType{}
+	if !e.endless {
+		return ret
+	}
+	for _, odds := range enemyTable {
+		if odds.unlockWave > 0 && odds.unlockWave == e.wave {
+			ret = append(ret, odds.enemyType)
+		}
+	}
+	return ret
+}
+
+func (e *WaveController) isUnlocked(odds enemyOdds) bool {
+	if odds.unlockWave == 0 {
+		return true
+	}
+	return e.endless && e.wave >= odds.unlockWave
+}
+
 func (e *WaveController) GenerateNextWave() []enemy.EnemyType {
+	e.wave++
+
+	available := []enemyOdds{}
+	totalWeight := 0
+	for _, odds := range enemyTable {
+		if e.isUnlocked(odds) {
+			available = append(available, odds)
+			totalWeight += odds.weight
+		}
+	}
+
 	next_enemies := []enemy.EnemyType{}
 	var currentCost int64
 	for currentCost = 0; currentCost < e.resources; {
 		budget := e.resources - currentCost
-		random := rand.Intn(100)
-		if random < 75 {
-			// Add basic enemy
-			next_enemies = append(next_enemies, enemy.EnemyTypeBasic)
-			currentCost += 1
-		} else if random < 90 {
-			// Try to add fast enemy
-			if budget >= 2 {
-				next_enemies = append(next_enemies, enemy.EnemyTypeFast)
-				currentCost += 2
-			} else {
-				next_enemies = append(next_enemies, enemy.EnemyTypeBasic)
-				currentCost += 1
-			}
-		} else {
-			// Try to add tank
-			if budget >= 4 {
-				next_enemies = append(next_enemies, enemy.EnemyTypeTank)
-				currentCost += 4
-			} else if budget >= 2 {
-				next_enemies = append(next_enemies, enemy.EnemyTypeFast)
-				currentCost += 2
-			} else {
-				next_enemies = append(next_enemies, enemy.EnemyTypeBasic)
-				currentCost += 1
+		random := rand.Intn(totalWeight)
+		picked := 0
+		for i, odds := range available {
+			if random < odds.weight {
+				picked = i
+				break
 			}
+			random -= odds.weight
+		}
+		// Fall back to cheaper enemies if the budget does not suffice.
+		for picked > 0 && enemy.Value(available[picked].enemyType) > budget {
+			picked--
 		}
+		next_enemies = append(next_enemies, available[picked].enemyType)
+		currentCost += enemy.Value(available[picked].enemyType)
 	}
-	fmt.Print(next_enemies)
 	return next_enemies
 }
 
 func (e *WaveController) IncreaseResources() {
 	e.resources += int64(float64(e.resources) * 0.1)
-	fmt.Printf("Next Wave Budget: %d", e.resources)
 }
 
 func (e *WaveController) Reset() {
 	e.resources = 100
+	e.wave = 0
 }
 
 func (e *WaveController) Deinit() {